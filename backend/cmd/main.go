@@ -1,16 +1,32 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/zyy125/my-blog/backend/config"
 	"github.com/zyy125/my-blog/backend/internal/pkg/database"
+	"github.com/zyy125/my-blog/backend/internal/repository"
 	"github.com/zyy125/my-blog/backend/internal/router"
+	"github.com/zyy125/my-blog/backend/internal/service"
 	"github.com/gin-gonic/gin"
 )
 
 func main() {
+	// 子命令：清理孤立上传文件
+	if len(os.Args) > 1 && os.Args[1] == "media-cleanup" {
+		runMediaCleanup(os.Args[2:])
+		return
+	}
+
 	// ========== 1. 加载配置 ==========
 	if err := config.LoadConfig("config/config.yaml"); err != nil {
 		log. Fatalf("配置加载失败: %v", err)
@@ -30,13 +46,82 @@ func main() {
 	}
 	fmt.Println("数据表迁移成功")
 
-	// ========== 4. 设置路由 ==========
+	// ========== 4. 初始化上传存储 ==========
+	if err := router.SetupUpload(); err != nil {
+		log.Fatalf("上传存储初始化失败: %v", err)
+	}
+
+	// ========== 5. 设置路由 ==========
 	gin.SetMode(config.App.Server.Mode)
-	r := router.SetupRouter()
+	r, cleanup := router.SetupRouter()
+
+	// ========== 6. 启动服务器 ==========
+	srv := &http.Server{
+		Addr:    config.App.Server.Port,
+		Handler: r,
+	}
+	go func() {
+		fmt.Printf("服务器启动在 http://localhost%s\n", config.App.Server.Port)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("服务器启动失败: %v", err)
+		}
+	}()
+
+	// ========== 7. 优雅退出 ==========
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+	fmt.Println("正在关闭服务器...")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Printf("服务器关闭失败: %v", err)
+	}
+
+	// 停止后台任务（写入剩余浏览量等）
+	cleanup()
+	fmt.Println("服务器已关闭")
+}
+
+// runMediaCleanup 清理未被任何内容引用的上传文件
+// 用法：blog media-cleanup [-dry-run=false] [-min-age=24h]
+func runMediaCleanup(args []string) {
+	fs := flag.NewFlagSet("media-cleanup", flag.ExitOnError)
+	configPath := fs.String("config", "config/config.yaml", "配置文件路径")
+	dryRun := fs.Bool("dry-run", true, "仅列出孤立文件，不实际删除")
+	minAge := fs.Duration("min-age", 24*time.Hour, "只清理早于该时长之前上传的文件")
+	fs.Parse(args)
 
-	// ========== 5. 启动服务器 ==========
-	fmt.Printf("服务器启动在 http://localhost%s\n", config.App.Server.Port)
-	if err := r.Run(config.App.Server.Port); err != nil {
-		log.Fatalf("服务器启动失败: %v", err)
+	// 1. 加载配置，连接数据库并初始化上传存储
+	if err := config.LoadConfig(*configPath); err != nil {
+		log.Fatalf("配置加载失败: %v", err)
+	}
+	if err := database.InitDB(config.App.Database.DSN()); err != nil {
+		log.Fatalf("数据库初始化失败: %v", err)
+	}
+	if err := database.AutoMigrate(); err != nil {
+		log.Fatalf("数据表迁移失败: %v", err)
+	}
+	if err := router.SetupUpload(); err != nil {
+		log.Fatalf("上传存储初始化失败: %v", err)
+	}
+
+	// 2. 清理
+	mediaService := service.NewMediaService(repository.NewMediaRepository(database.DB))
+	result, err := mediaService.CleanupOrphans(context.Background(), *minAge, *dryRun)
+	if err != nil {
+		log.Fatalf("清理失败: %v", err)
+	}
+
+	// 3. 输出结果
+	for _, file := range result.Files {
+		fmt.Println(file)
+	}
+	if result.DryRun {
+		fmt.Printf("发现 %d 个孤立文件（共 %d 字节），%d 条媒体记录待删除；使用 -dry-run=false 执行删除\n",
+			len(result.Files), result.Bytes, result.Records)
+		return
 	}
-}
\ No newline at end of file
+	fmt.Printf("已删除 %d 个孤立文件（共 %d 字节），%d 条媒体记录\n", len(result.Files), result.Bytes, result.Records)
+}
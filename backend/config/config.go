@@ -8,9 +8,15 @@ import (
 
 // Config 全局配置结构体
 type Config struct {
-	Server   ServerConfig   `mapstructure:"server"`
-	Database DatabaseConfig `mapstructure:"database"`
-	Admin    AdminConfig    `mapstructure:"admin"`
+	Server    ServerConfig    `mapstructure:"server"`
+	Database  DatabaseConfig  `mapstructure:"database"`
+	Admin     AdminConfig     `mapstructure:"admin"`
+	View      ViewConfig      `mapstructure:"view"`
+	Reaction  ReactionConfig  `mapstructure:"reaction"`
+	LinkCheck LinkCheckConfig `mapstructure:"link_check"`
+	Image     ImageConfig     `mapstructure:"image"`
+	Storage   StorageConfig   `mapstructure:"storage"`
+	Upload    UploadConfig    `mapstructure:"upload"`
 }
 
 // ServerConfig 服务器配置
@@ -34,6 +40,71 @@ type AdminConfig struct {
 	TokenExpireHours int    `mapstructure:"token_expire_hours"` // Token过期时间（小时）
 }
 
+// ViewConfig 浏览量统计配置
+type ViewConfig struct {
+	DedupWindowMinutes   int `mapstructure:"dedup_window_minutes"`   // 同一访客重复浏览的去重窗口（分钟）
+	FlushIntervalSeconds int `mapstructure:"flush_interval_seconds"` // 浏览量批量写入数据库的间隔（秒）
+}
+
+// ReactionConfig 文章表态配置
+type ReactionConfig struct {
+	Types []string `mapstructure:"types"` // 可用的表态（emoji）列表
+}
+
+// LinkCheckConfig 文章死链检查配置
+type LinkCheckConfig struct {
+	IntervalHours  int    `mapstructure:"interval_hours"`  // 定时检查间隔（小时）
+	TimeoutSeconds int    `mapstructure:"timeout_seconds"` // 外部链接请求超时（秒）
+	Concurrency    int    `mapstructure:"concurrency"`     // 外部链接并发检查数
+	UserAgent      string `mapstructure:"user_agent"`      // 请求外部链接时使用的 User-Agent
+	SiteURL        string `mapstructure:"site_url"`        // 本站地址（以此开头的绝对链接按站内链接检查）
+}
+
+// ImageConfig 上传图片处理配置
+type ImageConfig struct {
+	Widths      []int `mapstructure:"widths"`       // 生成的响应式图片宽度
+	ThumbWidth  int   `mapstructure:"thumb_width"`  // 封面缩略图宽度
+	ThumbHeight int   `mapstructure:"thumb_height"` // 封面缩略图高度
+	Quality     int   `mapstructure:"quality"`      // JPEG 编码质量（1-100）
+}
+
+// StorageConfig 上传文件存储配置
+type StorageConfig struct {
+	Driver    string   `mapstructure:"driver"`     // 存储类型: local 或 s3
+	LocalPath string   `mapstructure:"local_path"` // 本地存储目录（driver 为 local 时使用）
+	QuotaMB   int64    `mapstructure:"quota_mb"`   // 上传文件总配额（MB，含图片衍生版本），为 0 时不限制
+	S3        S3Config `mapstructure:"s3"`
+}
+
+// S3Config S3 兼容对象存储配置（AWS S3、MinIO、OSS 等）
+type S3Config struct {
+	Endpoint  string `mapstructure:"endpoint"`   // 服务地址，如 https://s3.amazonaws.com、http://minio:9000
+	Region    string `mapstructure:"region"`     // 区域
+	Bucket    string `mapstructure:"bucket"`     // 存储桶
+	AccessKey string `mapstructure:"access_key"` // 访问密钥 ID
+	SecretKey string `mapstructure:"secret_key"` // 访问密钥
+	PathStyle bool   `mapstructure:"path_style"` // 使用路径风格地址（MinIO 需要开启）
+	PublicURL string `mapstructure:"public_url"` // 文件访问地址前缀（如 CDN），为空时使用存储服务地址
+}
+
+// UploadConfig 文件上传配置
+type UploadConfig struct {
+	PartialDir         string `mapstructure:"partial_dir"`          // 分片上传（断点续传）的临时数据目录
+	PartialExpireHours int    `mapstructure:"partial_expire_hours"` // 分片上传任务的保留时间（小时）
+
+	// 各类文件的上传策略
+	Image    UploadPolicyConfig `mapstructure:"image"`
+	Document UploadPolicyConfig `mapstructure:"document"` // pdf、docx、pptx、xlsx
+	Archive  UploadPolicyConfig `mapstructure:"archive"`  // zip、gz、7z、rar
+	Audio    UploadPolicyConfig `mapstructure:"audio"`    // mp3、m4a、ogg、wav、flac
+}
+
+// UploadPolicyConfig 某类文件的上传策略
+type UploadPolicyConfig struct {
+	MaxSizeMB    int      `mapstructure:"max_size_mb"`   // 最大文件大小（MB），为 0 时不允许上传（图片使用默认的 10MB）
+	AllowedTypes []string `mapstructure:"allowed_types"` // 允许的 MIME 类型，为空时允许该类所有支持的类型
+}
+
 // App 全局配置实例
 var App *Config
 
@@ -5,6 +5,8 @@ go 1.25.3
 require (
 	github.com/gin-gonic/gin v1.11.0
 	github.com/spf13/viper v1.21.0
+	golang.org/x/crypto v0.46.0
+	golang.org/x/image v0.34.0
 	gorm.io/driver/mysql v1.6.0
 	gorm.io/gorm v1.31.1
 )
@@ -47,7 +49,6 @@ require (
 	go.uber.org/mock v0.6.0 // indirect
 	go.yaml.in/yaml/v3 v3.0.4 // indirect
 	golang.org/x/arch v0.23.0 // indirect
-	golang.org/x/crypto v0.46.0 // indirect
 	golang.org/x/net v0.48.0 // indirect
 	golang.org/x/sys v0.40.0 // indirect
 	golang.org/x/text v0.32.0 // indirect
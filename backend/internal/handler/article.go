@@ -2,23 +2,31 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"strconv"
 	
 	"github.com/gin-gonic/gin"
 	"github.com/zyy125/my-blog/backend/internal/handler/dto"
 	"github.com/zyy125/my-blog/backend/internal/model"
+	"github.com/zyy125/my-blog/backend/internal/pkg/limiter"
 	"github.com/zyy125/my-blog/backend/internal/pkg/response"
 	"github.com/zyy125/my-blog/backend/internal/service"
 )
 
 // ArticleHandler 文章控制器
 type ArticleHandler struct {
-	service *service.ArticleService
+	service       *service.ArticleService
+	unlockLimiter *limiter.FailureLimiter // 加密文章密码尝试限制（按 IP 和文章）
 }
 
 // NewArticleHandler 创建文章控制器实例
 func NewArticleHandler(service *service.ArticleService) *ArticleHandler {
-	return &ArticleHandler{service: service}
+	// 启动清理过期解锁 token 的协程
+	go unlockStore.cleanExpiredTokens()
+	return &ArticleHandler{
+		service:       service,
+		unlockLimiter: limiter.NewFailureLimiter(unlockMaxFailures, unlockFailureWindow),
+	}
 }
 
 // Create 创建文章（重写：支持标签）
@@ -36,16 +44,26 @@ func (h *ArticleHandler) Create(c *gin.Context) {
 	// 2. DTO 转 Model
 	article := &model. Article{
 		Title:      req.Title,
+		Slug:       req.Slug,
+		Language:   req.Language,
+		TranslationGroupID: req.TranslationOf,
 		Content:    req.Content,
 		Summary:    req.Summary,
 		CoverImg:   req.CoverImg,
 		CategoryID: req.CategoryID,
 		Status:     req.Status,
 		IsTop:      req.IsTop,
+		Password:   req.Password,
+		Metadata:   req.Metadata,
+	}
+	
+	// 作者登录时，文章归属当前作者
+	if authorID, ok := CurrentAuthorID(c); ok {
+		article.AuthorID = &authorID
 	}
 	
 	// 3. 调用 Service 创建（带标签）
-	if err := h.service.CreateWithTags(ctx, article, req.TagIDs); err != nil {
+	if err := h.service.CreateWithTags(ctx, article, req.TagIDs, req.Tags); err != nil {
 		response. Error(c, err.Error())
 		return
 	}
@@ -66,6 +84,11 @@ func (h *ArticleHandler) Update(c *gin.Context) {
 		return
 	}
 	
+	// 作者只能修改自己的文章
+	if !h.checkOwner(c, uint(id)) {
+		return
+	}
+	
 	// 2. 绑定请求参数
 	var req dto.UpdateArticleRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -77,16 +100,21 @@ func (h *ArticleHandler) Update(c *gin.Context) {
 	article := &model.Article{
 		ID:         uint(id),
 		Title:      req.Title,
+		Slug:       req.Slug,
+		Language:   req.Language,
+		TranslationGroupID: req.TranslationOf,
 		Content:    req.Content,
 		Summary:    req.Summary,
 		CoverImg:   req.CoverImg,
 		CategoryID:  req.CategoryID,
 		Status:     req.Status,
 		IsTop:      req.IsTop,
+		Password:   req.Password,
+		Metadata:   req.Metadata,
 	}
 	
 	// 4. 调用 Service 更新（带标签）
-	if err := h.service.UpdateWithTags(ctx, article, req. TagIDs); err != nil {
+	if err := h.service.UpdateWithTags(ctx, article, req. TagIDs, req.Tags); err != nil {
 		response.Error(c, err.Error())
 		return
 	}
@@ -96,7 +124,7 @@ func (h *ArticleHandler) Update(c *gin.Context) {
 }
 
 // List 获取文章列表（重写：支持高级筛选）
-// GET /api/articles? page=1&page_size=10&category_id=1&tag_id=2&keyword=Go
+// GET /api/articles? page=1&page_size=10&category_id=1&include_children=true&tag_id=2&keyword=Go&lang=en&meta[difficulty]=easy
 func (h *ArticleHandler) List(c *gin.Context) {
 	ctx := context.Background()
 	
@@ -115,6 +143,12 @@ func (h *ArticleHandler) List(c *gin.Context) {
 		query.PageSize = 10
 	}
 	
+	// 通用筛选：语言、自定义字段（meta[key]=value）
+	filter := service.ArticleFilter{
+		Language: query.Language,
+		Metadata: c.QueryMap("meta"),
+	}
+	
 	var articles []*model.Article
 	var total int64
 	var err error
@@ -122,16 +156,19 @@ func (h *ArticleHandler) List(c *gin.Context) {
 	// 3. 根据不同条件查询
 	if query.CategoryID != nil {
 		// 按分类查询
-		articles, total, err = h. service.ListByCategory(ctx, *query.CategoryID, query.Page, query.PageSize)
+		articles, total, err = h. service.ListByCategory(ctx, *query.CategoryID, query.IncludeChildren, query.Page, query.PageSize, filter)
 	} else if query.TagID != nil {
 		// 按标签查询
-		articles, total, err = h.service.ListByTag(ctx, *query. TagID, query.Page, query.PageSize)
+		articles, total, err = h.service.ListByTag(ctx, *query. TagID, query.Page, query.PageSize, filter)
+	} else if query.AuthorID != nil {
+		// 按作者查询
+		articles, total, err = h.service.ListByAuthor(ctx, *query.AuthorID, query.Page, query.PageSize, filter)
 	} else if query.Keyword != "" {
 		// 搜索
-		articles, total, err = h.service.Search(ctx, query.Keyword, query.Page, query.PageSize)
+		articles, total, err = h.service.Search(ctx, query.Keyword, query.Page, query.PageSize, filter)
 	} else {
 		// 普通列表查询
-		articles, total, err = h.service.List(ctx, query.Page, query.PageSize, query.Status)
+		articles, total, err = h.service.List(ctx, query.Page, query.PageSize, query.Status, filter)
 	}
 	
 	if err != nil {
@@ -154,12 +191,57 @@ func (h *ArticleHandler) GetByID(c *gin. Context) {
 		return
 	}
 	
-	article, err := h.service.GetByID(ctx, uint(id))
+	h.respondDetail(ctx, c, uint(id))
+}
+
+// GetBySlug 根据别名获取文章详情（按 ?lang= 或 Accept-Language 选择语言版本）
+// GET /api/articles/slug/:slug
+func (h *ArticleHandler) GetBySlug(c *gin.Context) {
+	ctx := context.Background()
+	
+	id, err := h.service.ResolveSlug(ctx, c.Param("slug"), preferredLanguages(c))
+	if err != nil {
+		response.NotFound(c, err.Error())
+		return
+	}
+	
+	h.respondDetail(ctx, c, id)
+}
+
+// respondDetail 返回文章详情并记录浏览
+func (h *ArticleHandler) respondDetail(ctx context.Context, c *gin.Context, id uint) {
+	// 持有有效的解锁 Token 或管理员 Token 可直接查看加密文章
+	unlocked := CheckToken(c.GetHeader("X-Admin-Token")) ||
+		h.isUnlocked(ctx, c.GetHeader("X-Article-Token"), id)
+	
+	article, err := h.service.GetByID(ctx, id, unlocked)
+	if errors.Is(err, service.ErrArticleLocked) {
+		response.Forbidden(c, err.Error(), article)
+		return
+	}
 	if err != nil {
 		response.NotFound(c, err.Error())
 		return
 	}
 	
+	// 记录浏览（管理员预览不计数）
+	if !CheckToken(c.GetHeader("X-Admin-Token")) {
+		// 前端通过 ref 参数传入 document.referrer，未传时使用 Referer 头
+		referrer, ok := c.GetQuery("ref")
+		if !ok {
+			referrer = c.Request.Referer()
+		}
+		h.service.RecordView(id, service.ViewInfo{
+			IP:          c.ClientIP(),
+			UserAgent:   c.Request.UserAgent(),
+			Referrer:    referrer,
+			SiteHost:    c.Request.Host,
+			UTMSource:   c.Query("utm_source"),
+			UTMMedium:   c.Query("utm_medium"),
+			UTMCampaign: c.Query("utm_campaign"),
+		})
+	}
+	
 	response.Success(c, article)
 }
 
@@ -174,10 +256,55 @@ func (h *ArticleHandler) Delete(c *gin.Context) {
 		return
 	}
 	
+	// 作者只能删除自己的文章
+	if !h.checkOwner(c, uint(id)) {
+		return
+	}
+	
 	if err := h.service.Delete(ctx, uint(id)); err != nil {
 		response.Error(c, err.Error())
 		return
 	}
 	
 	response.SuccessWithMsg(c, nil, "删除成功")
-}
\ No newline at end of file
+}
+
+// ListByAuthor 获取作者的文章列表
+// GET /api/authors/:id/articles?page=1&page_size=10&lang=en
+func (h *ArticleHandler) ListByAuthor(c *gin.Context) {
+	ctx := context.Background()
+	
+	authorID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.Error(c, "作者ID格式错误")
+		return
+	}
+	
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	
+	articles, total, err := h.service.ListByAuthor(ctx, uint(authorID), page, pageSize, service.ArticleFilter{
+		Language: c.Query("lang"),
+		Metadata: c.QueryMap("meta"),
+	})
+	if err != nil {
+		response.Error(c, err.Error())
+		return
+	}
+	
+	response.PageSuccess(c, articles, total, page, pageSize)
+}
+
+// checkOwner 作者登录时检查文章归属，不通过时写入错误响应
+func (h *ArticleHandler) checkOwner(c *gin.Context, id uint) bool {
+	authorID, ok := CurrentAuthorID(c)
+	if !ok {
+		return true // 共享密钥登录的管理员可操作所有文章
+	}
+	
+	if err := h.service.CheckOwner(context.Background(), id, authorID); err != nil {
+		response.Forbidden(c, err.Error(), nil)
+		return false
+	}
+	return true
+}
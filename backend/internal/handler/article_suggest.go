@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zyy125/my-blog/backend/internal/handler/dto"
+	"github.com/zyy125/my-blog/backend/internal/pkg/response"
+)
+
+// SuggestTags 根据文章内容推荐标签（已有标签在前，新标签在后）
+// POST /api/admin/articles/suggest-tags
+func (h *ArticleHandler) SuggestTags(c *gin.Context) {
+	ctx := context.Background()
+
+	var req dto.TagSuggestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, "参数格式错误: "+err.Error())
+		return
+	}
+
+	suggestions, err := h.service.SuggestTags(ctx, req.Title, req.Content, req.ArticleID, req.Limit)
+	if err != nil {
+		response.ServerError(c, "推荐失败: "+err.Error())
+		return
+	}
+
+	response.Success(c, suggestions)
+}
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zyy125/my-blog/backend/internal/handler/dto"
+	"github.com/zyy125/my-blog/backend/internal/pkg/response"
+)
+
+const (
+	unlockTokenTTL      = 30 * time.Minute // 解锁 Token 有效期
+	unlockMaxFailures   = 5                // 窗口内允许的密码错误次数
+	unlockFailureWindow = 15 * time.Minute // 密码错误统计窗口
+)
+
+// UnlockStore 存储加密文章的解锁 token
+type UnlockStore struct {
+	tokens map[string]unlockEntry // token -> 解锁信息
+	mu     sync.RWMutex
+}
+
+// unlockEntry 解锁信息
+type unlockEntry struct {
+	articleID       uint
+	passwordVersion string // 解锁时的密码版本，修改或取消密码后 token 失效
+	expiresAt       time.Time
+}
+
+var unlockStore = &UnlockStore{
+	tokens: make(map[string]unlockEntry),
+}
+
+// Unlock 验证加密文章密码并返回解锁 token
+// POST /api/articles/:id/unlock
+func (h *ArticleHandler) Unlock(c *gin.Context) {
+	ctx := context.Background()
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.Error(c, "ID格式错误")
+		return
+	}
+
+	var req dto.UnlockArticleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, "请输入密码")
+		return
+	}
+
+	// 1. 检查同一 IP 对该文章是否尝试过于频繁（原子地占用一次尝试机会）
+	limitKey := c.ClientIP() + ":" + strconv.FormatUint(id, 10)
+	if !h.unlockLimiter.Reserve(limitKey) {
+		response.TooManyRequests(c, "密码错误次数过多，请稍后再试")
+		return
+	}
+
+	// 2. 校验密码（成功时归还尝试机会，失败则计入失败次数）
+	version, err := h.service.VerifyPassword(ctx, uint(id), req.Password)
+	if err != nil {
+		response.Error(c, err.Error())
+		return
+	}
+	h.unlockLimiter.Release(limitKey)
+
+	// 3. 生成解锁 token
+	token, err := generateToken()
+	if err != nil {
+		response.ServerError(c, "生成 Token 失败")
+		return
+	}
+
+	expiresAt := time.Now().Add(unlockTokenTTL)
+	unlockStore.addToken(token, uint(id), version, expiresAt)
+
+	response.Success(c, dto.UnlockArticleResponse{
+		Token:     token,
+		ExpiresAt: expiresAt.Unix(),
+	})
+}
+
+// RemovePassword 取消文章加密
+// DELETE /api/admin/articles/:id/password
+func (h *ArticleHandler) RemovePassword(c *gin.Context) {
+	ctx := context.Background()
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.Error(c, "ID格式错误")
+		return
+	}
+
+	if !h.checkOwner(c, uint(id)) {
+		return
+	}
+
+	if err := h.service.RemovePassword(ctx, uint(id)); err != nil {
+		response.Error(c, err.Error())
+		return
+	}
+
+	response.SuccessWithMsg(c, nil, "已取消加密")
+}
+
+// addToken 添加 token
+func (us *UnlockStore) addToken(token string, articleID uint, passwordVersion string, expiresAt time.Time) {
+	us.mu.Lock()
+	defer us.mu.Unlock()
+	us.tokens[token] = unlockEntry{articleID: articleID, passwordVersion: passwordVersion, expiresAt: expiresAt}
+}
+
+// passwordVersion 返回 token 解锁时的密码版本，token 无效、已过期或不属于该文章时返回 false
+func (us *UnlockStore) passwordVersion(token string, articleID uint) (string, bool) {
+	if token == "" {
+		return "", false
+	}
+
+	us.mu.RLock()
+	defer us.mu.RUnlock()
+
+	entry, exists := us.tokens[token]
+	if !exists || entry.articleID != articleID || !time.Now().Before(entry.expiresAt) {
+		return "", false
+	}
+	return entry.passwordVersion, true
+}
+
+// isUnlocked 检查解锁 token 是否仍可查看指定文章（文章密码修改或取消后失效）
+func (h *ArticleHandler) isUnlocked(ctx context.Context, token string, articleID uint) bool {
+	version, ok := unlockStore.passwordVersion(token, articleID)
+	if !ok {
+		return false
+	}
+	current, err := h.service.PasswordVersion(ctx, articleID)
+	return err == nil && current != "" && current == version
+}
+
+// cleanExpiredTokens 定期清理过期 token
+func (us *UnlockStore) cleanExpiredTokens() {
+	ticker := time.NewTicker(10 * time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		us.mu.Lock()
+		now := time.Now()
+		for token, entry := range us.tokens {
+			if now.After(entry.expiresAt) {
+				delete(us.tokens, token)
+			}
+		}
+		us.mu.Unlock()
+	}
+}
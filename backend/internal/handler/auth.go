@@ -1,45 +1,72 @@
 package handler
 
 import (
+	"context"
 	"crypto/rand"
+	"crypto/subtle"
 	"encoding/base64"
 	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/zyy125/my-blog/backend/config"
+	"github.com/zyy125/my-blog/backend/internal/model"
+	"github.com/zyy125/my-blog/backend/internal/pkg/limiter"
 	"github.com/zyy125/my-blog/backend/internal/pkg/response"
+	"github.com/zyy125/my-blog/backend/internal/service"
+)
+
+// AuthorIDKey 认证通过后当前作者 ID 在 gin.Context 中的键
+const AuthorIDKey = "author_id"
+
+const (
+	loginMaxFailures   = 5                // 窗口内允许的登录失败次数
+	loginFailureWindow = 15 * time.Minute // 登录失败统计窗口
 )
 
 // TokenStore 存储有效的 token
 type TokenStore struct {
-	tokens map[string]time.Time // token -> 过期时间
+	tokens map[string]tokenSession // token -> 会话信息
 	mu     sync.RWMutex
 }
 
+// tokenSession token 会话信息
+type tokenSession struct {
+	authorID  uint // 作者 ID，0 表示使用共享密钥登录的管理员
+	expiresAt time.Time
+}
+
 var tokenStore = &TokenStore{
-	tokens: make(map[string]time.Time),
+	tokens: make(map[string]tokenSession),
 }
 
 // AuthHandler 认证处理器
-type AuthHandler struct{}
+type AuthHandler struct {
+	authorService *service.AuthorService
+	loginLimiter  *limiter.FailureLimiter // 登录尝试限制（按 IP）
+}
 
 // NewAuthHandler 创建认证处理器
-func NewAuthHandler() *AuthHandler {
+func NewAuthHandler(authorService *service.AuthorService) *AuthHandler {
 	// 启动清理过期 token 的协程
 	go tokenStore.cleanExpiredTokens()
-	return &AuthHandler{}
+	return &AuthHandler{
+		authorService: authorService,
+		loginLimiter:  limiter.NewFailureLimiter(loginMaxFailures, loginFailureWindow),
+	}
 }
 
 // VerifyRequest 验证请求结构
 type VerifyRequest struct {
+	Username  string `json:"username"` // 作者登录名（为空时使用共享管理密钥）
 	SecretKey string `json:"secret_key" binding:"required"`
 }
 
 // VerifyResponse 验证响应结构
 type VerifyResponse struct {
-	Token     string `json:"token"`
-	ExpiresAt int64  `json:"expires_at"` // Unix 时间戳
+	Token     string        `json:"token"`
+	ExpiresAt int64         `json:"expires_at"`       // Unix 时间戳
+	Author    *model.Author `json:"author,omitempty"` // 当前作者（共享密钥登录时为空）
 }
 
 // VerifyKey 验证密钥并返回临时 token
@@ -51,11 +78,27 @@ func (h *AuthHandler) VerifyKey(c *gin.Context) {
 		return
 	}
 
-	// 验证密钥
-	if req.SecretKey != config.App.Admin.SecretKey {
+	// 同一 IP 登录失败过多时拒绝（原子地占用一次尝试机会，验证成功后归还）
+	limitKey := c.ClientIP()
+	if !h.loginLimiter.Reserve(limitKey) {
+		response.TooManyRequests(c, "登录失败次数过多，请稍后再试")
+		return
+	}
+
+	// 验证密钥：指定登录名时校验作者密钥，否则校验共享管理密钥
+	var author *model.Author
+	if req.Username != "" {
+		var err error
+		author, err = h.authorService.Authenticate(context.Background(), req.Username, req.SecretKey)
+		if err != nil {
+			response.Error(c, err.Error())
+			return
+		}
+	} else if subtle.ConstantTimeCompare([]byte(req.SecretKey), []byte(config.App.Admin.SecretKey)) != 1 {
 		response.Error(c, "密钥错误")
 		return
 	}
+	h.loginLimiter.Release(limitKey)
 
 	// 生成临时 token
 	token, err := generateToken()
@@ -66,17 +109,38 @@ func (h *AuthHandler) VerifyKey(c *gin.Context) {
 
 	// 设置 token 过期时间（默认 2 小时）
 	expiresAt := time.Now().Add(time.Duration(config.App.Admin.TokenExpireHours) * time.Hour)
-	tokenStore.addToken(token, expiresAt)
+	var authorID uint
+	if author != nil {
+		authorID = author.ID
+	}
+	tokenStore.addToken(token, authorID, expiresAt)
 
 	response.Success(c, VerifyResponse{
 		Token:     token,
 		ExpiresAt: expiresAt.Unix(),
+		Author:    author,
 	})
 }
 
 // CheckToken 检查 token 是否有效（内部使用）
 func CheckToken(token string) bool {
-	return tokenStore.isValid(token)
+	_, ok := tokenStore.lookup(token)
+	return ok
+}
+
+// TokenAuthorID 返回 token 对应的作者 ID（0 表示共享密钥登录）
+func TokenAuthorID(token string) (uint, bool) {
+	return tokenStore.lookup(token)
+}
+
+// CurrentAuthorID 获取当前请求的作者 ID（共享密钥登录时返回 false）
+func CurrentAuthorID(c *gin.Context) (uint, bool) {
+	value, exists := c.Get(AuthorIDKey)
+	if !exists {
+		return 0, false
+	}
+	authorID, ok := value.(uint)
+	return authorID, ok && authorID != 0
 }
 
 // generateToken 生成随机 token
@@ -89,28 +153,28 @@ func generateToken() (string, error) {
 }
 
 // addToken 添加 token
-func (ts *TokenStore) addToken(token string, expiresAt time.Time) {
+func (ts *TokenStore) addToken(token string, authorID uint, expiresAt time.Time) {
 	ts.mu.Lock()
 	defer ts.mu.Unlock()
-	ts.tokens[token] = expiresAt
+	ts.tokens[token] = tokenSession{authorID: authorID, expiresAt: expiresAt}
 }
 
-// isValid 检查 token 是否有效
-func (ts *TokenStore) isValid(token string) bool {
+// lookup 检查 token 是否有效，并返回对应的作者 ID
+func (ts *TokenStore) lookup(token string) (uint, bool) {
 	ts.mu.RLock()
 	defer ts.mu.RUnlock()
 
-	expiresAt, exists := ts.tokens[token]
+	session, exists := ts.tokens[token]
 	if !exists {
-		return false
+		return 0, false
 	}
 
 	// 检查是否过期
-	if time.Now().After(expiresAt) {
-		return false
+	if time.Now().After(session.expiresAt) {
+		return 0, false
 	}
 
-	return true
+	return session.authorID, true
 }
 
 // cleanExpiredTokens 定期清理过期 token
@@ -121,8 +185,8 @@ func (ts *TokenStore) cleanExpiredTokens() {
 	for range ticker.C {
 		ts.mu.Lock()
 		now := time.Now()
-		for token, expiresAt := range ts.tokens {
-			if now.After(expiresAt) {
+		for token, session := range ts.tokens {
+			if now.After(session.expiresAt) {
 				delete(ts.tokens, token)
 			}
 		}
@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zyy125/my-blog/backend/internal/handler/dto"
+	"github.com/zyy125/my-blog/backend/internal/model"
+	"github.com/zyy125/my-blog/backend/internal/pkg/response"
+	"github.com/zyy125/my-blog/backend/internal/service"
+)
+
+// AuthorHandler 作者控制器
+type AuthorHandler struct {
+	service *service.AuthorService
+}
+
+// NewAuthorHandler 创建作者控制器实例
+func NewAuthorHandler(service *service.AuthorService) *AuthorHandler {
+	return &AuthorHandler{service: service}
+}
+
+// Create 创建作者
+// POST /api/admin/authors
+func (h *AuthorHandler) Create(c *gin.Context) {
+	ctx := context.Background()
+
+	// 只有使用共享密钥登录的管理员可以创建作者（创建作者即创建登录账号）
+	if _, ok := CurrentAuthorID(c); ok {
+		response.Forbidden(c, "无权创建作者", nil)
+		return
+	}
+
+	var req dto.CreateAuthorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, "参数格式错误: "+err.Error())
+		return
+	}
+
+	author := &model.Author{
+		Username:    req.Username,
+		Password:    req.Password,
+		Name:        req.Name,
+		Bio:         req.Bio,
+		Avatar:      req.Avatar,
+		SocialLinks: req.SocialLinks,
+	}
+
+	if err := h.service.Create(ctx, author); err != nil {
+		response.Error(c, err.Error())
+		return
+	}
+
+	response.SuccessWithMsg(c, dto.NewAuthorAccountResponse(author), "创建成功")
+}
+
+// List 获取作者列表
+// GET /api/authors
+func (h *AuthorHandler) List(c *gin.Context) {
+	ctx := context.Background()
+
+	authors, err := h.service.List(ctx)
+	if err != nil {
+		response.ServerError(c, "查询失败: "+err.Error())
+		return
+	}
+
+	response.Success(c, authors)
+}
+
+// ListAccounts 获取作者账号列表（含登录名；作者登录时只返回自己的账号）
+// GET /api/admin/authors
+func (h *AuthorHandler) ListAccounts(c *gin.Context) {
+	ctx := context.Background()
+
+	authors, err := h.service.List(ctx)
+	if err != nil {
+		response.ServerError(c, "查询失败: "+err.Error())
+		return
+	}
+
+	authorID, isAuthor := CurrentAuthorID(c)
+	accounts := make([]dto.AuthorAccountResponse, 0, len(authors))
+	for _, author := range authors {
+		if !isAuthor || author.ID == authorID {
+			accounts = append(accounts, dto.NewAuthorAccountResponse(author))
+		}
+	}
+
+	response.Success(c, accounts)
+}
+
+// GetByID 获取作者详情
+// GET /api/authors/:id
+func (h *AuthorHandler) GetByID(c *gin.Context) {
+	ctx := context.Background()
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.Error(c, "ID格式错误")
+		return
+	}
+
+	author, err := h.service.GetByID(ctx, uint(id))
+	if err != nil {
+		response.NotFound(c, err.Error())
+		return
+	}
+
+	response.Success(c, author)
+}
+
+// Update 更新作者
+// PUT /api/admin/authors/:id
+func (h *AuthorHandler) Update(c *gin.Context) {
+	ctx := context.Background()
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.Error(c, "ID格式错误")
+		return
+	}
+
+	// 作者只能修改自己的资料
+	if authorID, ok := CurrentAuthorID(c); ok && authorID != uint(id) {
+		response.Forbidden(c, "无权修改其他作者的资料", nil)
+		return
+	}
+
+	var req dto.UpdateAuthorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, "参数格式错误: "+err.Error())
+		return
+	}
+
+	author := &model.Author{
+		ID:          uint(id),
+		Username:    req.Username,
+		Password:    req.Password,
+		Name:        req.Name,
+		Bio:         req.Bio,
+		Avatar:      req.Avatar,
+		SocialLinks: req.SocialLinks,
+	}
+
+	if err := h.service.Update(ctx, author); err != nil {
+		response.Error(c, err.Error())
+		return
+	}
+
+	response.SuccessWithMsg(c, dto.NewAuthorAccountResponse(author), "更新成功")
+}
+
+// Delete 删除作者
+// DELETE /api/admin/authors/:id
+func (h *AuthorHandler) Delete(c *gin.Context) {
+	ctx := context.Background()
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.Error(c, "ID格式错误")
+		return
+	}
+
+	// 只有使用共享密钥登录的管理员可以删除作者
+	if _, ok := CurrentAuthorID(c); ok {
+		response.Forbidden(c, "无权删除作者", nil)
+		return
+	}
+
+	if err := h.service.Delete(ctx, uint(id)); err != nil {
+		response.Error(c, err.Error())
+		return
+	}
+
+	response.SuccessWithMsg(c, nil, "删除成功")
+}
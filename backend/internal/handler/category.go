@@ -5,6 +5,7 @@ import (
 	"strconv"
 	
 	"github.com/gin-gonic/gin"
+	"github.com/zyy125/my-blog/backend/internal/handler/dto"
 	"github.com/zyy125/my-blog/backend/internal/model"
 	"github.com/zyy125/my-blog/backend/internal/pkg/response"
 	"github.com/zyy125/my-blog/backend/internal/service"
@@ -53,6 +54,20 @@ func (h *CategoryHandler) List(c *gin.Context) {
 	response.Success(c, categories)
 }
 
+// Tree 获取分类树
+// GET /api/categories/tree
+func (h *CategoryHandler) Tree(c *gin.Context) {
+	ctx := context.Background()
+	
+	tree, err := h.service.Tree(ctx)
+	if err != nil {
+		response.ServerError(c, "查询失败: "+err.Error())
+		return
+	}
+	
+	response.Success(c, tree)
+}
+
 // GetByID 获取分类详情
 // GET /api/categories/:id
 func (h *CategoryHandler) GetByID(c *gin.Context) {
@@ -73,7 +88,21 @@ func (h *CategoryHandler) GetByID(c *gin.Context) {
 	response.Success(c, category)
 }
 
-// Update 更新分类
+// GetBySlug 根据 URL 别名获取分类详情
+// GET /api/categories/slug/:slug
+func (h *CategoryHandler) GetBySlug(c *gin.Context) {
+	ctx := context.Background()
+	
+	category, err := h.service.GetBySlug(ctx, c.Param("slug"))
+	if err != nil {
+		response.NotFound(c, err.Error())
+		return
+	}
+	
+	response.Success(c, category)
+}
+
+// Update 更新分类（只更新请求中出现的字段）
 // PUT /api/admin/categories/:id
 func (h *CategoryHandler) Update(c *gin.Context) {
 	ctx := context.Background()
@@ -84,15 +113,21 @@ func (h *CategoryHandler) Update(c *gin.Context) {
 		return
 	}
 	
-	var category model.Category
-	if err := c.ShouldBindJSON(&category); err != nil {
+	var req dto.UpdateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
 		response.Error(c, "参数格式错误: "+err.Error())
 		return
 	}
 	
-	category.ID = uint(id)
+	// 在现有分类上只修改请求中出现的字段
+	category, err := h.service.GetByID(ctx, uint(id))
+	if err != nil {
+		response.NotFound(c, err.Error())
+		return
+	}
+	columns := req.Apply(category)
 	
-	if err := h.service. Update(ctx, &category); err != nil {
+	if err := h.service. Update(ctx, category, columns); err != nil {
 		response.Error(c, err.Error())
 		return
 	}
@@ -101,7 +136,8 @@ func (h *CategoryHandler) Update(c *gin.Context) {
 }
 
 // Delete 删除分类
-// DELETE /api/admin/categories/:id
+// DELETE /api/admin/categories/:id?reassign_to=5
+// reassign_to 为目标分类 ID 时将文章转移到该分类，为 none 时文章变为未分类；不传时分类下有文章则拒绝删除
 func (h *CategoryHandler) Delete(c *gin.Context) {
 	ctx := context.Background()
 	
@@ -111,7 +147,22 @@ func (h *CategoryHandler) Delete(c *gin.Context) {
 		return
 	}
 	
-	if err := h.service.Delete(ctx, uint(id)); err != nil {
+	reassign := false
+	var targetID *uint
+	if value := c.Query("reassign_to"); value != "" {
+		reassign = true
+		if value != "none" {
+			target, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				response.Error(c, "reassign_to 格式错误")
+				return
+			}
+			t := uint(target)
+			targetID = &t
+		}
+	}
+	
+	if err := h.service.Delete(ctx, uint(id), reassign, targetID); err != nil {
 		response.Error(c, err.Error())
 		return
 	}
@@ -119,6 +170,31 @@ func (h *CategoryHandler) Delete(c *gin.Context) {
 	response. SuccessWithMsg(c, nil, "删除成功")
 }
 
+// Merge 将分类合并到目标分类（转移全部文章和子分类后删除源分类）
+// POST /api/admin/categories/:id/merge
+func (h *CategoryHandler) Merge(c *gin.Context) {
+	ctx := context.Background()
+	
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.Error(c, "ID格式错误")
+		return
+	}
+	
+	var req dto.CategoryMergeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, "参数格式错误: "+err.Error())
+		return
+	}
+	
+	if err := h.service.Merge(ctx, uint(id), req.TargetID); err != nil {
+		response.Error(c, err.Error())
+		return
+	}
+	
+	response.SuccessWithMsg(c, nil, "合并成功")
+}
+
 // ListWithCount 获取分类列表（带文章统计）
 // GET /api/categories/stats
 func (h *CategoryHandler) ListWithCount(c *gin. Context) {
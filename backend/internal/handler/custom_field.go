@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zyy125/my-blog/backend/internal/handler/dto"
+	"github.com/zyy125/my-blog/backend/internal/model"
+	"github.com/zyy125/my-blog/backend/internal/pkg/response"
+	"github.com/zyy125/my-blog/backend/internal/service"
+)
+
+// CustomFieldHandler 自定义字段控制器
+type CustomFieldHandler struct {
+	service *service.CustomFieldService
+}
+
+// NewCustomFieldHandler 创建自定义字段控制器实例
+func NewCustomFieldHandler(service *service.CustomFieldService) *CustomFieldHandler {
+	return &CustomFieldHandler{service: service}
+}
+
+// Create 创建自定义字段
+// POST /api/admin/custom-fields
+func (h *CustomFieldHandler) Create(c *gin.Context) {
+	ctx := context.Background()
+
+	var req dto.CustomFieldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, "参数格式错误: "+err.Error())
+		return
+	}
+
+	field := newCustomField(&req)
+	field.Name = req.Name
+
+	if err := h.service.Create(ctx, field); err != nil {
+		response.Error(c, err.Error())
+		return
+	}
+
+	response.SuccessWithMsg(c, field, "创建成功")
+}
+
+// List 获取自定义字段列表
+// GET /api/custom-fields
+func (h *CustomFieldHandler) List(c *gin.Context) {
+	ctx := context.Background()
+
+	fields, err := h.service.List(ctx)
+	if err != nil {
+		response.ServerError(c, "查询失败: "+err.Error())
+		return
+	}
+
+	response.Success(c, fields)
+}
+
+// Update 更新自定义字段
+// PUT /api/admin/custom-fields/:id
+func (h *CustomFieldHandler) Update(c *gin.Context) {
+	ctx := context.Background()
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.Error(c, "ID格式错误")
+		return
+	}
+
+	var req dto.CustomFieldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, "参数格式错误: "+err.Error())
+		return
+	}
+
+	field := newCustomField(&req)
+	field.ID = uint(id)
+
+	if err := h.service.Update(ctx, field); err != nil {
+		response.Error(c, err.Error())
+		return
+	}
+
+	response.SuccessWithMsg(c, field, "更新成功")
+}
+
+// Delete 删除自定义字段
+// DELETE /api/admin/custom-fields/:id
+func (h *CustomFieldHandler) Delete(c *gin.Context) {
+	ctx := context.Background()
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.Error(c, "ID格式错误")
+		return
+	}
+
+	if err := h.service.Delete(ctx, uint(id)); err != nil {
+		response.Error(c, err.Error())
+		return
+	}
+
+	response.SuccessWithMsg(c, nil, "删除成功")
+}
+
+// newCustomField 根据请求构造字段定义（字段名、ID 由调用方按需设置）
+func newCustomField(req *dto.CustomFieldRequest) *model.CustomField {
+	return &model.CustomField{
+		Label:     req.Label,
+		Type:      req.Type,
+		Required:  req.Required,
+		Options:   req.Options,
+		Pattern:   req.Pattern,
+		MaxLength: req.MaxLength,
+	}
+}
@@ -3,25 +3,48 @@ package dto
 // CreateArticleRequest 创建文章请求
 type CreateArticleRequest struct {
 	Title      string  `json:"title" binding:"required"`        // 标题（必填）
+	Slug       string  `json:"slug"`                            // URL 别名（可选）
+	Language   string  `json:"language"`                        // 语言代码（默认 zh）
+	TranslationOf *uint `json:"translation_of"`                 // 作为哪篇文章的翻译（可选）
 	Content    string  `json:"content" binding:"required"`      // 内容（必填）
 	Summary    string  `json:"summary"`                         // 摘要（可选）
 	CoverImg   string  `json:"cover_img"`                       // 封面图（可选）
 	CategoryID *uint   `json:"category_id"`                     // 分类ID（可选）
 	TagIDs     []uint  `json:"tag_ids"`                         // 标签ID列表
+	Tags       []string `json:"tags"`                           // 标签名称列表（不存在的标签自动创建，别名解析为对应标签）
 	Status     int8    `json:"status"`                          // 状态：0草稿 1已发布
 	IsTop      bool    `json:"is_top"`                          // 是否置顶
+	Password   string  `json:"password"`                        // 访问密码（可选，设置后文章需解锁查看）
+	Metadata   map[string]interface{} `json:"metadata"`         // 自定义字段值
 }
 
 // UpdateArticleRequest 更新文章请求
 type UpdateArticleRequest struct {
 	Title      string  `json:"title" binding:"required"`
+	Slug       string  `json:"slug"`
+	Language   string  `json:"language"`
+	TranslationOf *uint `json:"translation_of"` // 留空表示保留原翻译关联
 	Content    string  `json:"content" binding:"required"`
 	Summary    string  `json:"summary"`
 	CoverImg   string  `json:"cover_img"`
 	CategoryID *uint   `json:"category_id"`
 	TagIDs     []uint  `json:"tag_ids"`
+	Tags       []string `json:"tags"` // 标签名称列表（与 tag_ids 合并）
 	Status     int8    `json:"status"`
 	IsTop      bool    `json:"is_top"`
+	Password   string  `json:"password"` // 新访问密码（留空表示不修改）
+	Metadata   map[string]interface{} `json:"metadata"`
+}
+
+// UnlockArticleRequest 解锁加密文章请求
+type UnlockArticleRequest struct {
+	Password string `json:"password" binding:"required"`
+}
+
+// UnlockArticleResponse 解锁加密文章响应
+type UnlockArticleResponse struct {
+	Token     string `json:"token"`      // 解锁 Token（请求详情时通过 X-Article-Token 携带）
+	ExpiresAt int64  `json:"expires_at"` // Unix 时间戳
 }
 
 // ArticleListQuery 文章列表查询参数
@@ -30,6 +53,9 @@ type ArticleListQuery struct {
 	PageSize   int   `form:"page_size"`   // 每页数量
 	Status     *int8 `form:"status"`      // 状态筛选
 	CategoryID *uint `form:"category_id"` // 分类筛选
+	IncludeChildren bool `form:"include_children"` // 按分类筛选时包含子孙分类
 	TagID      *uint `form:"tag_id"`      // 标签筛选
+	AuthorID   *uint `form:"author_id"`   // 作者筛选
+	Language   string `form:"lang"`       // 语言筛选
 	Keyword    string `form:"keyword"`     // 关键词搜索
 }
\ No newline at end of file
@@ -0,0 +1,34 @@
+package dto
+
+import "github.com/zyy125/my-blog/backend/internal/model"
+
+// CreateAuthorRequest 创建作者请求
+type CreateAuthorRequest struct {
+	Username    string            `json:"username" binding:"required"` // 登录名（必填）
+	Password    string            `json:"password" binding:"required"` // 登录密钥（必填）
+	Name        string            `json:"name" binding:"required"`     // 显示名称（必填）
+	Bio         string            `json:"bio"`                         // 个人简介
+	Avatar      string            `json:"avatar"`                      // 头像
+	SocialLinks map[string]string `json:"social_links"`                // 社交链接
+}
+
+// UpdateAuthorRequest 更新作者请求
+type UpdateAuthorRequest struct {
+	Username    string            `json:"username" binding:"required"`
+	Password    string            `json:"password"` // 留空表示不修改
+	Name        string            `json:"name" binding:"required"`
+	Bio         string            `json:"bio"`
+	Avatar      string            `json:"avatar"`
+	SocialLinks map[string]string `json:"social_links"`
+}
+
+// AuthorAccountResponse 作者账号信息（包含登录名，只在管理接口中返回）
+type AuthorAccountResponse struct {
+	*model.Author
+	Username string `json:"username"`
+}
+
+// NewAuthorAccountResponse 创建作者账号信息
+func NewAuthorAccountResponse(author *model.Author) AuthorAccountResponse {
+	return AuthorAccountResponse{Author: author, Username: author.Username}
+}
@@ -0,0 +1,66 @@
+package dto
+
+import "github.com/zyy125/my-blog/backend/internal/model"
+
+// CategoryMergeRequest 合并分类请求
+type CategoryMergeRequest struct {
+	TargetID uint `json:"target_id" binding:"required"` // 目标分类ID
+}
+
+// UpdateCategoryRequest 更新分类请求（只更新请求中出现的字段，未传的字段保持不变）
+type UpdateCategoryRequest struct {
+	Name            *string `json:"name"`
+	Description     *string `json:"description"`
+	ParentID        *uint   `json:"parent_id"` // 为 0 时移动为顶级分类
+	SortOrder       *int    `json:"sort_order"`
+	Slug            *string `json:"slug"`
+	SEOTitle        *string `json:"seo_title"`
+	MetaDescription *string `json:"meta_description"`
+	CoverImg        *string `json:"cover_img"`
+	Intro           *string `json:"intro"`
+}
+
+// Apply 将请求中出现的字段写入分类，返回需要更新的列名
+func (r *UpdateCategoryRequest) Apply(category *model.Category) []string {
+	var columns []string
+	if r.Name != nil {
+		category.Name = *r.Name
+		columns = append(columns, "name")
+	}
+	if r.Description != nil {
+		category.Description = *r.Description
+		columns = append(columns, "description")
+	}
+	if r.ParentID != nil {
+		category.ParentID = r.ParentID
+		if *r.ParentID == 0 {
+			category.ParentID = nil
+		}
+		columns = append(columns, "parent_id")
+	}
+	if r.SortOrder != nil {
+		category.SortOrder = *r.SortOrder
+		columns = append(columns, "sort_order")
+	}
+	if r.Slug != nil {
+		category.Slug = r.Slug
+		columns = append(columns, "slug")
+	}
+	if r.SEOTitle != nil {
+		category.SEOTitle = *r.SEOTitle
+		columns = append(columns, "seo_title")
+	}
+	if r.MetaDescription != nil {
+		category.MetaDescription = *r.MetaDescription
+		columns = append(columns, "meta_description")
+	}
+	if r.CoverImg != nil {
+		category.CoverImg = *r.CoverImg
+		columns = append(columns, "cover_img")
+	}
+	if r.Intro != nil {
+		category.Intro = *r.Intro
+		columns = append(columns, "intro")
+	}
+	return columns
+}
@@ -0,0 +1,12 @@
+package dto
+
+// CustomFieldRequest 创建/更新自定义字段请求
+type CustomFieldRequest struct {
+	Name      string   `json:"name"`                    // 字段名（仅创建时有效，创建后不可修改）
+	Label     string   `json:"label"`                   // 显示名称
+	Type      string   `json:"type" binding:"required"` // 字段类型：string/number/boolean/url/select（必填）
+	Required  bool     `json:"required"`                // 是否必填
+	Options   []string `json:"options"`                 // 可选值（select 类型）
+	Pattern   string   `json:"pattern"`                 // 正则校验（string 类型，可选）
+	MaxLength int      `json:"max_length"`              // 最大长度（string 类型，0 表示不限制）
+}
@@ -0,0 +1,16 @@
+package dto
+
+// PageRequest 创建/更新独立页面请求
+type PageRequest struct {
+	Title     string `json:"title" binding:"required"`   // 标题（必填）
+	Slug      string `json:"slug" binding:"required"`    // URL 别名（必填）
+	Content   string `json:"content" binding:"required"` // Markdown 内容（必填）
+	MenuOrder int    `json:"menu_order"`                 // 菜单排序
+	Status    int8   `json:"status"`                     // 状态：0草稿 1已发布
+}
+
+// ConvertArticleToPageRequest 文章转页面请求
+type ConvertArticleToPageRequest struct {
+	Slug      string `json:"slug"`       // 页面别名（留空则沿用文章别名）
+	MenuOrder int    `json:"menu_order"` // 菜单排序
+}
@@ -0,0 +1,6 @@
+package dto
+
+// ReactionRequest 文章表态请求
+type ReactionRequest struct {
+	Type string `json:"type" binding:"required"` // 表态类型（emoji）
+}
@@ -0,0 +1,61 @@
+package dto
+
+import "github.com/zyy125/my-blog/backend/internal/model"
+
+// TagMergeRequest 合并标签请求
+type TagMergeRequest struct {
+	TargetID uint `json:"target_id" binding:"required"` // 目标标签ID
+}
+
+// TagAliasRequest 添加标签别名请求
+type TagAliasRequest struct {
+	Alias string `json:"alias" binding:"required"` // 别名
+}
+
+// TagSuggestRequest 标签推荐请求
+type TagSuggestRequest struct {
+	ArticleID uint   `json:"article_id"`                 // 正在编辑的文章ID（不计入语料，可选）
+	Title     string `json:"title"`                      // 标题
+	Content   string `json:"content" binding:"required"` // Markdown 正文
+	Limit     int    `json:"limit"`                      // 已有标签和新标签各返回的最大数量（默认 10）
+}
+
+// UpdateTagRequest 更新标签请求（只更新请求中出现的字段，未传的字段保持不变）
+type UpdateTagRequest struct {
+	Name            *string `json:"name"`
+	Slug            *string `json:"slug"`
+	SEOTitle        *string `json:"seo_title"`
+	MetaDescription *string `json:"meta_description"`
+	CoverImg        *string `json:"cover_img"`
+	Intro           *string `json:"intro"`
+}
+
+// Apply 将请求中出现的字段写入标签，返回需要更新的列名
+func (r *UpdateTagRequest) Apply(tag *model.Tag) []string {
+	var columns []string
+	if r.Name != nil {
+		tag.Name = *r.Name
+		columns = append(columns, "name")
+	}
+	if r.Slug != nil {
+		tag.Slug = r.Slug
+		columns = append(columns, "slug")
+	}
+	if r.SEOTitle != nil {
+		tag.SEOTitle = *r.SEOTitle
+		columns = append(columns, "seo_title")
+	}
+	if r.MetaDescription != nil {
+		tag.MetaDescription = *r.MetaDescription
+		columns = append(columns, "meta_description")
+	}
+	if r.CoverImg != nil {
+		tag.CoverImg = *r.CoverImg
+		columns = append(columns, "cover_img")
+	}
+	if r.Intro != nil {
+		tag.Intro = *r.Intro
+		columns = append(columns, "intro")
+	}
+	return columns
+}
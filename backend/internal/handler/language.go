@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// preferredLanguages 解析请求的语言偏好：优先使用 ?lang= 参数，否则按 Accept-Language 的权重排序
+func preferredLanguages(c *gin.Context) []string {
+	if lang := c.Query("lang"); lang != "" {
+		return []string{lang}
+	}
+	return parseAcceptLanguage(c.GetHeader("Accept-Language"))
+}
+
+// parseAcceptLanguage 解析 Accept-Language 头，如 "en-US,en;q=0.9,zh;q=0.8"
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		lang string
+		q    float64
+	}
+
+	var items []weighted
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		lang := strings.TrimSpace(fields[0])
+		if lang == "" || lang == "*" {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if q > 0 {
+			items = append(items, weighted{lang: lang, q: q})
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].q > items[j].q
+	})
+
+	langs := make([]string, 0, len(items))
+	for _, item := range items {
+		langs = append(langs, item.lang)
+	}
+	return langs
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zyy125/my-blog/backend/internal/pkg/response"
+	"github.com/zyy125/my-blog/backend/internal/service"
+)
+
+// LinkCheckHandler 死链检查控制器
+type LinkCheckHandler struct {
+	checker *service.LinkChecker
+}
+
+// NewLinkCheckHandler 创建死链检查控制器实例
+func NewLinkCheckHandler(checker *service.LinkChecker) *LinkCheckHandler {
+	return &LinkCheckHandler{checker: checker}
+}
+
+// Report 获取死链报告（按文章和页面分组，可用 article_id 筛选文章）
+// GET /api/admin/link-check
+func (h *LinkCheckHandler) Report(c *gin.Context) {
+	ctx := context.Background()
+
+	var articleID *uint
+	if s := c.Query("article_id"); s != "" {
+		id, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			response.Error(c, "文章ID格式错误")
+			return
+		}
+		aid := uint(id)
+		articleID = &aid
+	}
+
+	report, err := h.checker.Report(ctx, articleID)
+	if err != nil {
+		response.ServerError(c, "查询失败: "+err.Error())
+		return
+	}
+
+	response.Success(c, report)
+}
+
+// Run 立即开始一次检查（后台执行）
+// POST /api/admin/link-check/run
+func (h *LinkCheckHandler) Run(c *gin.Context) {
+	if !h.checker.Trigger() {
+		response.Error(c, "链接检查正在进行中")
+		return
+	}
+
+	response.SuccessWithMsg(c, h.checker.Status(), "已开始检查")
+}
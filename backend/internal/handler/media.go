@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zyy125/my-blog/backend/internal/pkg/response"
+	"github.com/zyy125/my-blog/backend/internal/service"
+)
+
+// defaultOrphanMinAge 孤立文件清理默认只处理一天前上传的文件
+const defaultOrphanMinAge = 24 * time.Hour
+
+// MediaHandler 媒体库控制器
+type MediaHandler struct {
+	service *service.MediaService
+}
+
+// NewMediaHandler 创建媒体库控制器实例
+func NewMediaHandler(service *service.MediaService) *MediaHandler {
+	return &MediaHandler{service: service}
+}
+
+// List 获取媒体列表
+// GET /api/admin/media?page=1&page_size=20&keyword=xx&type=image
+func (h *MediaHandler) List(c *gin.Context) {
+	ctx := context.Background()
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	list, total, err := h.service.List(ctx, page, pageSize, c.Query("keyword"), c.Query("type"))
+	if err != nil {
+		response.ServerError(c, "查询失败: "+err.Error())
+		return
+	}
+
+	response.PageSuccess(c, list, total, page, pageSize)
+}
+
+// GetByID 获取媒体详情（含引用信息）
+// GET /api/admin/media/:id
+func (h *MediaHandler) GetByID(c *gin.Context) {
+	ctx := context.Background()
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.Error(c, "ID格式错误")
+		return
+	}
+
+	media, err := h.service.GetByID(ctx, uint(id))
+	if err != nil {
+		response.NotFound(c, err.Error())
+		return
+	}
+
+	response.Success(c, media)
+}
+
+// Delete 删除媒体文件（仍被引用时需要 force=true）
+// DELETE /api/admin/media/:id?force=true
+func (h *MediaHandler) Delete(c *gin.Context) {
+	ctx := context.Background()
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.Error(c, "ID格式错误")
+		return
+	}
+
+	force := c.Query("force") == "true"
+	if err := h.service.Delete(ctx, uint(id), force); err != nil {
+		response.Error(c, err.Error())
+		return
+	}
+
+	response.SuccessWithMsg(c, nil, "删除成功")
+}
+
+// Cleanup 清理未被引用的孤立文件（默认仅预览）
+// POST /api/admin/media/cleanup?dry_run=false&min_age_hours=24
+func (h *MediaHandler) Cleanup(c *gin.Context) {
+	ctx := context.Background()
+
+	dryRun := c.DefaultQuery("dry_run", "true") != "false"
+	minAge := defaultOrphanMinAge
+	if s := c.Query("min_age_hours"); s != "" {
+		hours, err := strconv.Atoi(s)
+		if err != nil || hours < 0 {
+			response.Error(c, "min_age_hours 格式错误")
+			return
+		}
+		minAge = time.Duration(hours) * time.Hour
+	}
+
+	result, err := h.service.CleanupOrphans(ctx, minAge, dryRun)
+	if err != nil {
+		response.ServerError(c, "清理失败: "+err.Error())
+		return
+	}
+
+	msg := "清理完成"
+	if dryRun {
+		msg = "预览完成（未删除文件）"
+	}
+	response.SuccessWithMsg(c, result, msg)
+}
+
+// Download 以附件形式下载文件并统计下载次数（支持断点续传的 Range 请求）
+// GET /download/*key
+func (h *MediaHandler) Download(c *gin.Context) {
+	ctx := context.Background()
+
+	// 1. 打开文件
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	media, body, info, err := h.service.Open(ctx, key)
+	if err != nil {
+		response.NotFound(c, err.Error())
+		return
+	}
+	defer body.Close()
+
+	// 2. 统计下载次数（分段请求只在从头开始时计数）
+	if rng := c.GetHeader("Range"); rng == "" || strings.HasPrefix(rng, "bytes=0-") {
+		if err := h.service.CountDownload(ctx, media.ID); err != nil {
+			log.Printf("记录下载次数失败: %v", err)
+		}
+	}
+
+	// 3. 以附件形式返回，使用上传时的原始文件名
+	c.Header("Content-Type", media.MimeType)
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": media.FileName}))
+	c.Header("X-Content-Type-Options", "nosniff")
+	if seeker, ok := body.(io.ReadSeeker); ok {
+		http.ServeContent(c.Writer, c.Request, media.FileName, info.ModTime, seeker)
+		return
+	}
+	c.Header("Content-Length", strconv.FormatInt(info.Size, 10))
+	c.Status(http.StatusOK)
+	io.Copy(c.Writer, body)
+}
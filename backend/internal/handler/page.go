@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zyy125/my-blog/backend/internal/handler/dto"
+	"github.com/zyy125/my-blog/backend/internal/model"
+	"github.com/zyy125/my-blog/backend/internal/pkg/response"
+	"github.com/zyy125/my-blog/backend/internal/service"
+)
+
+// PageHandler 独立页面控制器
+type PageHandler struct {
+	service  *service.PageService
+	articles *ArticleHandler // 文章转页面时复用文章的归属检查
+}
+
+// NewPageHandler 创建页面控制器实例
+func NewPageHandler(service *service.PageService, articles *ArticleHandler) *PageHandler {
+	return &PageHandler{service: service, articles: articles}
+}
+
+// Create 创建页面
+// POST /api/admin/pages
+func (h *PageHandler) Create(c *gin.Context) {
+	ctx := context.Background()
+
+	var req dto.PageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, "参数格式错误: "+err.Error())
+		return
+	}
+
+	page := &model.Page{
+		Title:     req.Title,
+		Slug:      req.Slug,
+		Content:   req.Content,
+		MenuOrder: req.MenuOrder,
+		Status:    req.Status,
+	}
+
+	if err := h.service.Create(ctx, page); err != nil {
+		response.Error(c, err.Error())
+		return
+	}
+
+	response.SuccessWithMsg(c, page, "创建成功")
+}
+
+// ListMenu 获取菜单页面列表（已发布，不含正文）
+// GET /api/pages
+func (h *PageHandler) ListMenu(c *gin.Context) {
+	ctx := context.Background()
+
+	pages, err := h.service.ListMenu(ctx)
+	if err != nil {
+		response.ServerError(c, "查询失败: "+err.Error())
+		return
+	}
+
+	response.Success(c, pages)
+}
+
+// GetBySlug 根据别名获取已发布页面
+// GET /api/pages/:slug
+func (h *PageHandler) GetBySlug(c *gin.Context) {
+	ctx := context.Background()
+
+	page, err := h.service.GetBySlug(ctx, c.Param("slug"))
+	if err != nil {
+		response.NotFound(c, err.Error())
+		return
+	}
+
+	response.Success(c, page)
+}
+
+// List 获取全部页面（含草稿）
+// GET /api/admin/pages
+func (h *PageHandler) List(c *gin.Context) {
+	ctx := context.Background()
+
+	var status *int8
+	if s := c.Query("status"); s != "" {
+		v, err := strconv.ParseInt(s, 10, 8)
+		if err != nil {
+			response.Error(c, "状态格式错误")
+			return
+		}
+		st := int8(v)
+		status = &st
+	}
+
+	pages, err := h.service.List(ctx, status)
+	if err != nil {
+		response.ServerError(c, "查询失败: "+err.Error())
+		return
+	}
+
+	response.Success(c, pages)
+}
+
+// GetByID 获取页面详情（含草稿）
+// GET /api/admin/pages/:id
+func (h *PageHandler) GetByID(c *gin.Context) {
+	ctx := context.Background()
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.Error(c, "ID格式错误")
+		return
+	}
+
+	page, err := h.service.GetByID(ctx, uint(id))
+	if err != nil {
+		response.NotFound(c, err.Error())
+		return
+	}
+
+	response.Success(c, page)
+}
+
+// Update 更新页面
+// PUT /api/admin/pages/:id
+func (h *PageHandler) Update(c *gin.Context) {
+	ctx := context.Background()
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.Error(c, "ID格式错误")
+		return
+	}
+
+	var req dto.PageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, "参数格式错误: "+err.Error())
+		return
+	}
+
+	page := &model.Page{
+		ID:        uint(id),
+		Title:     req.Title,
+		Slug:      req.Slug,
+		Content:   req.Content,
+		MenuOrder: req.MenuOrder,
+		Status:    req.Status,
+	}
+
+	if err := h.service.Update(ctx, page); err != nil {
+		response.Error(c, err.Error())
+		return
+	}
+
+	response.SuccessWithMsg(c, page, "更新成功")
+}
+
+// Delete 删除页面
+// DELETE /api/admin/pages/:id
+func (h *PageHandler) Delete(c *gin.Context) {
+	ctx := context.Background()
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.Error(c, "ID格式错误")
+		return
+	}
+
+	if err := h.service.Delete(ctx, uint(id)); err != nil {
+		response.Error(c, err.Error())
+		return
+	}
+
+	response.SuccessWithMsg(c, nil, "删除成功")
+}
+
+// ConvertFromArticle 把文章转换为独立页面（原文章会被删除）
+// POST /api/admin/articles/:id/convert-to-page
+func (h *PageHandler) ConvertFromArticle(c *gin.Context) {
+	ctx := context.Background()
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.Error(c, "ID格式错误")
+		return
+	}
+
+	// 转换会删除原文章，作者只能转换自己的文章
+	if !h.articles.checkOwner(c, uint(id)) {
+		return
+	}
+
+	var req dto.ConvertArticleToPageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, "参数格式错误: "+err.Error())
+		return
+	}
+
+	page, err := h.service.ConvertFromArticle(ctx, uint(id), req.Slug, req.MenuOrder)
+	if err != nil {
+		response.Error(c, err.Error())
+		return
+	}
+
+	response.SuccessWithMsg(c, page, "转换成功")
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zyy125/my-blog/backend/internal/handler/dto"
+	"github.com/zyy125/my-blog/backend/internal/pkg/limiter"
+	"github.com/zyy125/my-blog/backend/internal/pkg/response"
+	"github.com/zyy125/my-blog/backend/internal/service"
+)
+
+const (
+	fingerprintCookie = "blog_fp"          // 访客指纹 Cookie 名称
+	fingerprintMaxAge = 365 * 24 * 60 * 60 // Cookie 有效期（秒）
+
+	reactionMaxPerIP = 30               // 窗口内同一 IP 允许的表态次数
+	reactionWindow   = 10 * time.Minute // 表态次数统计窗口
+)
+
+// ReactionHandler 文章表态控制器
+type ReactionHandler struct {
+	service *service.ReactionService
+	limiter *limiter.FailureLimiter // 表态次数限制（按 IP），防止伪造指纹刷表态
+}
+
+// NewReactionHandler 创建表态控制器实例
+func NewReactionHandler(service *service.ReactionService) *ReactionHandler {
+	return &ReactionHandler{
+		service: service,
+		limiter: limiter.NewFailureLimiter(reactionMaxPerIP, reactionWindow),
+	}
+}
+
+// Types 获取可用的表态类型
+// GET /api/reactions/types
+func (h *ReactionHandler) Types(c *gin.Context) {
+	response.Success(c, h.service.Types())
+}
+
+// Add 对文章表态
+// POST /api/articles/:id/reactions
+func (h *ReactionHandler) Add(c *gin.Context) {
+	ctx := context.Background()
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.Error(c, "文章ID格式错误")
+		return
+	}
+
+	var req dto.ReactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, "参数格式错误: "+err.Error())
+		return
+	}
+
+	// 每次表态都占用一次机会（不归还），同一 IP 换指纹也无法无限刷表态
+	if !h.limiter.Reserve(c.ClientIP()) {
+		response.TooManyRequests(c, "操作过于频繁，请稍后再试")
+		return
+	}
+
+	result, err := h.service.Add(ctx, uint(id), req.Type, visitorFingerprint(c))
+	if err != nil {
+		response.Error(c, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Remove 取消对文章的表态
+// DELETE /api/articles/:id/reactions
+func (h *ReactionHandler) Remove(c *gin.Context) {
+	ctx := context.Background()
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.Error(c, "文章ID格式错误")
+		return
+	}
+
+	var req dto.ReactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, "参数格式错误: "+err.Error())
+		return
+	}
+
+	result, err := h.service.Remove(ctx, uint(id), req.Type, visitorFingerprint(c))
+	if err != nil {
+		response.Error(c, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// visitorFingerprint 计算访客指纹（指纹 Cookie + IP 的哈希），没有 Cookie 时下发新的
+// 新 Cookie 由 IP 和 User-Agent 派生而不是随机生成：不带 Cookie 的重复请求得到相同指纹，无法靠丢弃 Cookie 重复表态
+func visitorFingerprint(c *gin.Context) string {
+	cookie, err := c.Cookie(fingerprintCookie)
+	if err != nil || cookie == "" {
+		sum := sha256.Sum256([]byte(c.ClientIP() + "|" + c.Request.UserAgent()))
+		cookie = hex.EncodeToString(sum[:16])
+		c.SetCookie(fingerprintCookie, cookie, fingerprintMaxAge, "/", "", false, true)
+	}
+
+	sum := sha256.Sum256([]byte(cookie + "|" + c.ClientIP()))
+	return hex.EncodeToString(sum[:])
+}
@@ -2,19 +2,30 @@ package handler
 
 import (
 	"context"
+	"strconv"
+	"time"
 	
 	"github.com/gin-gonic/gin"
 	"github.com/zyy125/my-blog/backend/internal/model"
 	"github.com/zyy125/my-blog/backend/internal/pkg/database"
 	"github.com/zyy125/my-blog/backend/internal/pkg/response"
+	"github.com/zyy125/my-blog/backend/internal/service"
 )
 
 // StatsHandler 统计控制器
-type StatsHandler struct{}
+type StatsHandler struct {
+	service         *service.StatsService
+	reactionService *service.ReactionService
+	mediaService    *service.MediaService
+}
 
 // NewStatsHandler 创建统计控制器实例
-func NewStatsHandler() *StatsHandler {
-	return &StatsHandler{}
+func NewStatsHandler(service *service.StatsService, reactionService *service.ReactionService, mediaService *service.MediaService) *StatsHandler {
+	return &StatsHandler{
+		service:         service,
+		reactionService: reactionService,
+		mediaService:    mediaService,
+	}
 }
 
 // GetDashboard 获取后台首页统计数据
@@ -51,7 +62,32 @@ func (h *StatsHandler) GetDashboard(c *gin.Context) {
 	var totalViews int64
 	db.Model(&model.Article{}).Select("COALESCE(SUM(views), 0)").Scan(&totalViews)
 	
-	// 8. 返回统计数据
+	// 8. 最近 30 天每日浏览量
+	dailyViews, err := h.service.SiteViewTrend(ctx, 30)
+	if err != nil {
+		response.ServerError(c, "查询失败: "+err.Error())
+		return
+	}
+	
+	// 9. 表态统计
+	reactionTotals, err := h.reactionService.Totals(ctx)
+	if err != nil {
+		response.ServerError(c, "查询失败: "+err.Error())
+		return
+	}
+	var reactionCount int64
+	for _, total := range reactionTotals {
+		reactionCount += total.Count
+	}
+	
+	// 10. 上传存储用量
+	storageUsage, err := h.mediaService.Usage(ctx)
+	if err != nil {
+		response.ServerError(c, "查询失败: "+err.Error())
+		return
+	}
+	
+	// 11. 返回统计数据
 	response.Success(c, gin.H{
 		"article_count":          articleCount,
 		"published_count":        publishedCount,
@@ -60,5 +96,111 @@ func (h *StatsHandler) GetDashboard(c *gin.Context) {
 		"comment_count":          commentCount,
 		"pending_comment_count":   pendingCommentCount,
 		"total_views":            totalViews,
+		"daily_views":            dailyViews,
+		"reaction_count":         reactionCount,
+		"reaction_totals":        reactionTotals,
+		"storage":                storageUsage,
 	})
-}
\ No newline at end of file
+}
+
+// ArticleViewTrend 获取文章每日浏览量趋势
+// GET /api/admin/stats/articles/:id/views?days=30
+func (h *StatsHandler) ArticleViewTrend(c *gin.Context) {
+	ctx := context.Background()
+	
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.Error(c, "ID格式错误")
+		return
+	}
+	days, _ := strconv.Atoi(c.DefaultQuery("days", "30"))
+	
+	series, err := h.service.ArticleViewTrend(ctx, uint(id), days)
+	if err != nil {
+		response.Error(c, err.Error())
+		return
+	}
+	
+	response.Success(c, series)
+}
+
+// SiteViewTrend 获取全站每日浏览量趋势
+// GET /api/admin/stats/views?days=30
+func (h *StatsHandler) SiteViewTrend(c *gin.Context) {
+	ctx := context.Background()
+	
+	days, _ := strconv.Atoi(c.DefaultQuery("days", "30"))
+	
+	series, err := h.service.SiteViewTrend(ctx, days)
+	if err != nil {
+		response.ServerError(c, "查询失败: "+err.Error())
+		return
+	}
+	
+	response.Success(c, series)
+}
+
+// TopArticles 获取某日/周/月浏览量最高的文章
+// GET /api/admin/stats/top-articles?period=week&date=2026-01-01&limit=10
+func (h *StatsHandler) TopArticles(c *gin.Context) {
+	ctx := context.Background()
+	
+	date := time.Now()
+	if dateStr := c.Query("date"); dateStr != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", dateStr, time.Local)
+		if err != nil {
+			response.Error(c, "日期格式错误，应为 YYYY-MM-DD")
+			return
+		}
+		date = parsed
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	
+	articles, err := h.service.TopArticles(ctx, c.Query("period"), date, limit)
+	if err != nil {
+		response.Error(c, err.Error())
+		return
+	}
+	
+	response.Success(c, articles)
+}
+
+// SiteReferrers 获取全站来源和推广活动排行
+// GET /api/admin/stats/referrers?days=30&limit=10
+func (h *StatsHandler) SiteReferrers(c *gin.Context) {
+	ctx := context.Background()
+	
+	days, _ := strconv.Atoi(c.DefaultQuery("days", "30"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	
+	report, err := h.service.ReferrerReport(ctx, nil, days, limit)
+	if err != nil {
+		response.ServerError(c, "查询失败: "+err.Error())
+		return
+	}
+	
+	response.Success(c, report)
+}
+
+// ArticleReferrers 获取文章的来源和推广活动排行
+// GET /api/admin/stats/articles/:id/referrers?days=30&limit=10
+func (h *StatsHandler) ArticleReferrers(c *gin.Context) {
+	ctx := context.Background()
+	
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.Error(c, "ID格式错误")
+		return
+	}
+	days, _ := strconv.Atoi(c.DefaultQuery("days", "30"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	
+	articleID := uint(id)
+	report, err := h.service.ReferrerReport(ctx, &articleID, days, limit)
+	if err != nil {
+		response.Error(c, err.Error())
+		return
+	}
+	
+	response.Success(c, report)
+}
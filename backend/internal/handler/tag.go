@@ -5,6 +5,7 @@ import (
 	"strconv"
 	
 	"github.com/gin-gonic/gin"
+	"github.com/zyy125/my-blog/backend/internal/handler/dto"
 	"github.com/zyy125/my-blog/backend/internal/model"
 	"github.com/zyy125/my-blog/backend/internal/pkg/response"
 	"github.com/zyy125/my-blog/backend/internal/service"
@@ -73,7 +74,56 @@ func (h *TagHandler) GetByID(c *gin.Context) {
 	response.Success(c, tag)
 }
 
-// Update 更新标签
+// GetBySlug 根据 URL 别名获取标签详情
+// GET /api/tags/slug/:slug
+func (h *TagHandler) GetBySlug(c *gin.Context) {
+	ctx := context.Background()
+	
+	tag, err := h.service.GetBySlug(ctx, c.Param("slug"))
+	if err != nil {
+		response.NotFound(c, err.Error())
+		return
+	}
+	
+	response.Success(c, tag)
+}
+
+// Lookup 根据名称或别名查找标签（别名返回对应的标准标签）
+// GET /api/tags/lookup?name=golang
+func (h *TagHandler) Lookup(c *gin.Context) {
+	ctx := context.Background()
+	
+	name := c.Query("name")
+	if name == "" {
+		response.Error(c, "name 不能为空")
+		return
+	}
+	
+	tag, err := h.service.GetByName(ctx, name)
+	if err != nil {
+		response.NotFound(c, err.Error())
+		return
+	}
+	
+	response.Success(c, tag)
+}
+
+// Suggest 标签自动补全（按前缀匹配名称和别名）
+// GET /api/tags/suggest?q=go&limit=10
+func (h *TagHandler) Suggest(c *gin.Context) {
+	ctx := context.Background()
+	
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	tags, err := h.service.Suggest(ctx, c.Query("q"), limit)
+	if err != nil {
+		response.ServerError(c, "查询失败: "+err.Error())
+		return
+	}
+	
+	response.Success(c, tags)
+}
+
+// Update 更新标签（只更新请求中出现的字段）
 // PUT /api/admin/tags/: id
 func (h *TagHandler) Update(c *gin.Context) {
 	ctx := context.Background()
@@ -84,15 +134,21 @@ func (h *TagHandler) Update(c *gin.Context) {
 		return
 	}
 	
-	var tag model.Tag
-	if err := c.ShouldBindJSON(&tag); err != nil {
+	var req dto.UpdateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
 		response.Error(c, "参数格式错误: "+err.Error())
 		return
 	}
 	
-	tag.ID = uint(id)
+	// 在现有标签上只修改请求中出现的字段
+	tag, err := h.service.GetByID(ctx, uint(id))
+	if err != nil {
+		response.NotFound(c, err.Error())
+		return
+	}
+	columns := req.Apply(tag)
 	
-	if err := h.service. Update(ctx, &tag); err != nil {
+	if err := h.service. Update(ctx, tag, columns); err != nil {
 		response.Error(c, err.Error())
 		return
 	}
@@ -119,6 +175,31 @@ func (h *TagHandler) Delete(c *gin.Context) {
 	response. SuccessWithMsg(c, nil, "删除成功")
 }
 
+// Cloud 获取标签云（按已发布文章数计算权重）
+// GET /api/tags/cloud?min_count=1&top=50
+func (h *TagHandler) Cloud(c *gin.Context) {
+	ctx := context.Background()
+	
+	minCount, err := strconv.ParseInt(c.DefaultQuery("min_count", "1"), 10, 64)
+	if err != nil || minCount < 0 {
+		response.Error(c, "min_count 格式错误")
+		return
+	}
+	top, err := strconv.Atoi(c.DefaultQuery("top", "0"))
+	if err != nil || top < 0 {
+		response.Error(c, "top 格式错误")
+		return
+	}
+	
+	cloud, err := h.service.Cloud(ctx, minCount, top)
+	if err != nil {
+		response.ServerError(c, "查询失败: "+err.Error())
+		return
+	}
+	
+	response.Success(c, cloud)
+}
+
 // ListWithCount 获取标签列表（带文章统计）
 // GET /api/tags/stats
 func (h *TagHandler) ListWithCount(c *gin.Context) {
@@ -131,4 +212,79 @@ func (h *TagHandler) ListWithCount(c *gin.Context) {
 	}
 	
 	response.Success(c, tags)
+}
+
+// Merge 将标签合并到目标标签
+// POST /api/admin/tags/:id/merge
+func (h *TagHandler) Merge(c *gin.Context) {
+	ctx := context.Background()
+	
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.Error(c, "ID格式错误")
+		return
+	}
+	
+	var req dto.TagMergeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, "参数格式错误: "+err.Error())
+		return
+	}
+	
+	if err := h.service.Merge(ctx, uint(id), req.TargetID); err != nil {
+		response.Error(c, err.Error())
+		return
+	}
+	
+	response.SuccessWithMsg(c, nil, "合并成功")
+}
+
+// AddAlias 添加标签别名
+// POST /api/admin/tags/:id/aliases
+func (h *TagHandler) AddAlias(c *gin.Context) {
+	ctx := context.Background()
+	
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.Error(c, "ID格式错误")
+		return
+	}
+	
+	var req dto.TagAliasRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, "参数格式错误: "+err.Error())
+		return
+	}
+	
+	alias, err := h.service.AddAlias(ctx, uint(id), req.Alias)
+	if err != nil {
+		response.Error(c, err.Error())
+		return
+	}
+	
+	response.SuccessWithMsg(c, alias, "添加成功")
+}
+
+// DeleteAlias 删除标签别名
+// DELETE /api/admin/tags/:id/aliases/:alias_id
+func (h *TagHandler) DeleteAlias(c *gin.Context) {
+	ctx := context.Background()
+	
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.Error(c, "ID格式错误")
+		return
+	}
+	aliasID, err := strconv.ParseUint(c.Param("alias_id"), 10, 32)
+	if err != nil {
+		response.Error(c, "ID格式错误")
+		return
+	}
+	
+	if err := h.service.DeleteAlias(ctx, uint(id), uint(aliasID)); err != nil {
+		response.NotFound(c, err.Error())
+		return
+	}
+	
+	response.SuccessWithMsg(c, nil, "删除成功")
 }
\ No newline at end of file
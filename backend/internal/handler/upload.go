@@ -1,22 +1,34 @@
 package handler
 
 import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
 	"github.com/gin-gonic/gin"
+	"github.com/zyy125/my-blog/backend/internal/model"
 	"github.com/zyy125/my-blog/backend/internal/pkg/response"
 	"github.com/zyy125/my-blog/backend/internal/pkg/upload"
+	"github.com/zyy125/my-blog/backend/internal/service"
 )
 
 // UploadHandler 上传控制器
-type UploadHandler struct{}
+type UploadHandler struct {
+	mediaService *service.MediaService
+	resumable    *upload.ResumableStore // 断点续传的临时数据
+}
 
 // NewUploadHandler 创建上传控制器实例
-func NewUploadHandler() *UploadHandler {
-	return &UploadHandler{}
+func NewUploadHandler(mediaService *service.MediaService, resumable *upload.ResumableStore) *UploadHandler {
+	return &UploadHandler{mediaService: mediaService, resumable: resumable}
 }
 
 // UploadImage 上传图片
 // POST /api/admin/upload/image
 func (h *UploadHandler) UploadImage(c *gin.Context) {
+	ctx := context.Background()
+
 	// 1. 获取上传的文件
 	file, err := c.FormFile("file")
 	if err != nil {
@@ -25,14 +37,116 @@ func (h *UploadHandler) UploadImage(c *gin.Context) {
 	}
 
 	// 2. 保存文件
-	filePath, err := upload.SaveImage(file)
+	info, err := upload.SaveImage(ctx, file)
 	if err != nil {
 		response.Error(c, err.Error())
 		return
 	}
 
-	// 3. 返回文件URL
+	// 3. 记录到媒体库
+	media, err := h.record(ctx, c, info)
+	if err != nil {
+		response.ServerError(c, "保存文件记录失败: "+err.Error())
+		return
+	}
+
+	// 4. 返回文件URL及衍生版本（供前端构建 srcset）
 	response.Success(c, gin.H{
-		"url": filePath,
+		"url":       info.URL,
+		"media_id":  media.ID,
+		"width":     info.Width,
+		"height":    info.Height,
+		"variants":  media.Variants,
+		"thumbnail": thumbnailURL(info),
+		"srcset":    buildSrcset(info),
 	})
 }
+
+// UploadFile 上传附件（文档、压缩包、音频，也可以是图片），按文件类别的上传策略校验
+// POST /api/admin/upload/file
+func (h *UploadHandler) UploadFile(c *gin.Context) {
+	ctx := context.Background()
+
+	// 1. 获取上传的文件
+	file, err := c.FormFile("file")
+	if err != nil {
+		response.Error(c, "请选择要上传的文件")
+		return
+	}
+
+	// 2. 保存文件
+	info, err := upload.SaveFile(ctx, file, upload.DefaultConfig)
+	if err != nil {
+		response.Error(c, err.Error())
+		return
+	}
+
+	// 3. 记录到媒体库
+	media, err := h.record(ctx, c, info)
+	if err != nil {
+		response.ServerError(c, "保存文件记录失败: "+err.Error())
+		return
+	}
+
+	// 4. 返回插入内容时使用的地址（附件为下载地址）
+	response.Success(c, gin.H{
+		"url":       info.Link(),
+		"file_url":  info.URL,
+		"media_id":  media.ID,
+		"name":      info.Name,
+		"size":      info.Size,
+		"mime_type": info.MimeType,
+		"category":  info.Category,
+	})
+}
+
+// record 将已保存的文件记录到媒体库，失败时删除本次新写入的文件
+func (h *UploadHandler) record(ctx context.Context, c *gin.Context, info *upload.FileInfo) (*model.Media, error) {
+	var uploaderID *uint
+	if authorID, ok := CurrentAuthorID(c); ok {
+		uploaderID = &authorID
+	}
+	media, err := h.mediaService.Record(ctx, info, uploaderID)
+	if err != nil {
+		// 重复上传时文件属于已有记录，不能删除
+		if info.Existing {
+			return nil, err
+		}
+		urls := []string{info.URL}
+		for _, v := range info.Variants {
+			urls = append(urls, v.URL)
+		}
+		for _, url := range urls {
+			if removeErr := upload.Remove(ctx, upload.DefaultConfig, url); removeErr != nil {
+				log.Printf("删除上传文件失败: %v", removeErr)
+			}
+		}
+		return nil, err
+	}
+	return media, nil
+}
+
+// thumbnailURL 返回封面缩略图地址，没有缩略图时返回原图
+func thumbnailURL(info *upload.FileInfo) string {
+	for _, v := range info.Variants {
+		if v.Name == upload.ThumbnailVariant {
+			return v.URL
+		}
+	}
+	return info.URL
+}
+
+// buildSrcset 用响应式版本和原图构建 srcset（如 "/a-480w.jpg 480w, /a.jpg 1600w"）
+func buildSrcset(info *upload.FileInfo) string {
+	if info.Width == 0 {
+		return ""
+	}
+	var parts []string
+	for _, v := range info.Variants {
+		if v.Name != upload.ThumbnailVariant {
+			parts = append(parts, fmt.Sprintf("%s %dw", v.URL, v.Width))
+		}
+	}
+	parts = append(parts, fmt.Sprintf("%s %dw", info.URL, info.Width))
+	return strings.Join(parts, ", ")
+}
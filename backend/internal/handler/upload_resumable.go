@@ -0,0 +1,264 @@
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zyy125/my-blog/backend/internal/pkg/response"
+	"github.com/zyy125/my-blog/backend/internal/pkg/upload"
+)
+
+// statusChecksumMismatch tus checksum 扩展规定的校验失败状态码
+const statusChecksumMismatch = 460
+
+// 断点续传接口实现 tus 1.0.0 核心协议及 creation、expiration、checksum、termination 扩展：
+// 创建任务（POST）→ 逐段上传（PATCH，Upload-Offset 必须等于已接收大小）→ 中断后查询偏移量（HEAD）继续上传。
+// 最后一段上传完成后自动校验整体校验和（Upload-Metadata 中的 checksum），
+// 然后按普通上传流程校验保存并记录到媒体库，结果通过响应头 X-Upload-Url、X-Media-Id 返回。
+
+// ResumableOptions 查询断点续传支持的协议版本和扩展
+// OPTIONS /api/admin/upload/resumable
+func (h *UploadHandler) ResumableOptions(c *gin.Context) {
+	c.Header("Tus-Resumable", upload.TusVersion)
+	c.Header("Tus-Version", upload.TusVersion)
+	c.Header("Tus-Extension", "creation,expiration,checksum,termination")
+	c.Header("Tus-Max-Size", strconv.FormatInt(upload.DefaultConfig.MaxSize(), 10))
+	c.Header("Tus-Checksum-Algorithm", upload.TusChecksumAlgorithms)
+	c.Status(http.StatusNoContent)
+}
+
+// ResumableCreate 创建断点续传任务
+// POST /api/admin/upload/resumable
+// 请求头：Upload-Length（文件大小）、Upload-Metadata（filename 必填，checksum 可选，如 "sha256 <Base64>"）
+func (h *UploadHandler) ResumableCreate(c *gin.Context) {
+	ctx := context.Background()
+	if !checkTusVersion(c) {
+		return
+	}
+
+	// 1. 解析文件大小和元数据
+	if c.GetHeader("Upload-Defer-Length") != "" {
+		tusError(c, http.StatusBadRequest, "不支持延迟声明文件大小")
+		return
+	}
+	length, err := strconv.ParseInt(c.GetHeader("Upload-Length"), 10, 64)
+	if err != nil || length < 0 {
+		tusError(c, http.StatusBadRequest, "无效的 Upload-Length")
+		return
+	}
+	if length > upload.DefaultConfig.MaxSize() {
+		tusError(c, http.StatusRequestEntityTooLarge, "文件大小超过限制")
+		return
+	}
+	metadata, err := parseTusMetadata(c.GetHeader("Upload-Metadata"))
+	if err != nil {
+		tusError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	name := metadata["filename"]
+	if name == "" {
+		name = metadata["name"]
+	}
+
+	// 2. 创建任务
+	up, err := h.resumable.Create(ctx, name, length, metadata["checksum"], upload.DefaultConfig)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, upload.ErrQuotaExceeded) {
+			status = http.StatusRequestEntityTooLarge
+		} else if errors.Is(err, upload.ErrTooManyUploads) {
+			status = http.StatusTooManyRequests
+		}
+		tusError(c, status, err.Error())
+		return
+	}
+
+	// 3. 空文件没有数据需要上传，创建后直接保存
+	if up.Length == 0 && !h.finishResumable(ctx, c, up.ID) {
+		h.resumable.Delete(up.ID)
+		return
+	}
+
+	// 4. 返回任务地址
+	c.Header("Location", strings.TrimSuffix(c.Request.URL.Path, "/")+"/"+up.ID)
+	c.Header("Upload-Expires", up.ExpiresAt.UTC().Format(http.TimeFormat))
+	c.Status(http.StatusCreated)
+}
+
+// ResumableOffset 查询已接收的偏移量（断线后据此继续上传）
+// HEAD /api/admin/upload/resumable/:id
+func (h *UploadHandler) ResumableOffset(c *gin.Context) {
+	if !checkTusVersion(c) {
+		return
+	}
+
+	up, err := h.resumable.Get(c.Param("id"))
+	if err != nil {
+		tusUploadError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("Upload-Offset", strconv.FormatInt(up.Offset, 10))
+	c.Header("Upload-Length", strconv.FormatInt(up.Length, 10))
+	c.Header("Upload-Expires", up.ExpiresAt.UTC().Format(http.TimeFormat))
+	c.Status(http.StatusOK)
+}
+
+// ResumableWrite 上传一段数据，最后一段完成后保存文件
+// PATCH /api/admin/upload/resumable/:id
+// 请求头：Content-Type: application/offset+octet-stream、Upload-Offset、Upload-Checksum（可选）
+func (h *UploadHandler) ResumableWrite(c *gin.Context) {
+	ctx := context.Background()
+	if !checkTusVersion(c) {
+		return
+	}
+
+	// 1. 校验请求头
+	if c.ContentType() != "application/offset+octet-stream" {
+		tusError(c, http.StatusUnsupportedMediaType, "Content-Type 必须为 application/offset+octet-stream")
+		return
+	}
+	offset, err := strconv.ParseInt(c.GetHeader("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		tusError(c, http.StatusBadRequest, "无效的 Upload-Offset")
+		return
+	}
+	var checksum *upload.Checksum
+	if value := c.GetHeader("Upload-Checksum"); value != "" {
+		if checksum, err = upload.ParseChecksum(value); err != nil {
+			tusError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	// 2. 写入数据
+	id := c.Param("id")
+	up, err := h.resumable.Write(id, offset, c.Request.Body, checksum)
+	if err != nil {
+		tusUploadError(c, err)
+		return
+	}
+	c.Header("Upload-Offset", strconv.FormatInt(up.Offset, 10))
+	if up.Offset < up.Length {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	// 3. 全部接收后校验并保存文件
+	if !h.finishResumable(ctx, c, id) {
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// finishResumable 全部数据接收后校验并保存文件、记录到媒体库，失败时写入错误响应并返回 false
+func (h *UploadHandler) finishResumable(ctx context.Context, c *gin.Context, id string) bool {
+	// 1. 校验并保存文件
+	info, err := h.resumable.Complete(ctx, id, upload.DefaultConfig)
+	if err != nil {
+		tusUploadError(c, err)
+		return false
+	}
+
+	// 2. 记录到媒体库（失败时终止任务，客户端需重新上传）
+	media, err := h.record(ctx, c, info)
+	if err != nil {
+		h.resumable.Delete(id)
+		tusError(c, http.StatusInternalServerError, "保存文件记录失败: "+err.Error())
+		return false
+	}
+	h.resumable.SetMediaID(id, media.ID)
+
+	c.Header("X-Upload-Url", info.Link())
+	c.Header("X-Media-Id", strconv.FormatUint(uint64(media.ID), 10))
+	return true
+}
+
+// ResumableDelete 终止上传并删除已接收的数据
+// DELETE /api/admin/upload/resumable/:id
+func (h *UploadHandler) ResumableDelete(c *gin.Context) {
+	if !checkTusVersion(c) {
+		return
+	}
+
+	if err := h.resumable.Delete(c.Param("id")); err != nil {
+		tusUploadError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ResumableStatus 查询上传进度，完成后返回文件地址和媒体 ID
+// GET /api/admin/upload/resumable/:id
+func (h *UploadHandler) ResumableStatus(c *gin.Context) {
+	up, err := h.resumable.Get(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, upload.ErrUploadNotFound) {
+			response.NotFound(c, err.Error())
+			return
+		}
+		response.ServerError(c, err.Error())
+		return
+	}
+	response.Success(c, up)
+}
+
+// checkTusVersion 设置 Tus-Resumable 响应头，并检查客户端使用的协议版本
+func checkTusVersion(c *gin.Context) bool {
+	c.Header("Tus-Resumable", upload.TusVersion)
+	if c.GetHeader("Tus-Resumable") != upload.TusVersion {
+		c.Header("Tus-Version", upload.TusVersion)
+		tusError(c, http.StatusPreconditionFailed, "不支持的 tus 协议版本")
+		return false
+	}
+	return true
+}
+
+// tusUploadError 将分片上传的错误转换为 tus 协议规定的状态码
+func tusUploadError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, upload.ErrUploadNotFound):
+		tusError(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, upload.ErrOffsetMismatch):
+		tusError(c, http.StatusConflict, err.Error())
+	case errors.Is(err, upload.ErrUploadLocked):
+		tusError(c, http.StatusLocked, err.Error())
+	case errors.Is(err, upload.ErrExceedsLength):
+		tusError(c, http.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, upload.ErrChecksumMismatch):
+		tusError(c, statusChecksumMismatch, err.Error())
+	default:
+		tusError(c, http.StatusBadRequest, err.Error())
+	}
+}
+
+// tusError tus 接口的错误响应（纯文本，客户端会直接展示）
+func tusError(c *gin.Context, status int, msg string) {
+	c.String(status, msg)
+}
+
+// parseTusMetadata 解析 Upload-Metadata 头（"key base64value,key2 base64value2"，值可省略）
+func parseTusMetadata(header string) (map[string]string, error) {
+	metadata := make(map[string]string)
+	if strings.TrimSpace(header) == "" {
+		return metadata, nil
+	}
+	for _, pair := range strings.Split(header, ",") {
+		key, encoded, _ := strings.Cut(strings.TrimSpace(pair), " ")
+		if key == "" {
+			return nil, errors.New("无效的 Upload-Metadata")
+		}
+		value, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, errors.New("无效的 Upload-Metadata")
+		}
+		metadata[key] = string(value)
+	}
+	return metadata, nil
+}
@@ -18,13 +18,30 @@ func AdminAuth() gin.HandlerFunc {
 		}
 
 		// 2. 验证 Token 是否有效
-		if !handler.CheckToken(token) {
+		authorID, ok := handler.TokenAuthorID(token)
+		if !ok {
 			response.Error(c, "Token无效或已过期")
 			c.Abort()
 			return
 		}
 
-		// 3. 验证通过，继续处理请求
+		// 3. 记录当前作者，供后续处理器使用
+		c.Set(handler.AuthorIDKey, authorID)
+
+		// 4. 验证通过，继续处理请求
+		c.Next()
+	}
+}
+
+// AdminOnly 仅允许共享密钥登录的管理员访问（需在 AdminAuth 之后使用）
+// 作者账号只能管理自己的文章和上传文件，不能访问站点级的管理功能
+func AdminOnly() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, isAuthor := handler.CurrentAuthorID(c); isAuthor {
+			response.Forbidden(c, "仅管理员可以执行该操作", nil)
+			c.Abort()
+			return
+		}
 		c.Next()
 	}
 }
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zyy125/my-blog/backend/internal/pkg/upload"
+)
+
+// UploadHeaders 上传文件响应头中间件
+// 按扩展名（上传时由文件内容决定）设置 Content-Type，并禁止浏览器嗅探内容类型；
+// 非图片文件一律作为附件下载，不在浏览器中直接打开
+func UploadHeaders() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", upload.ContentType(c.Request.URL.Path))
+		c.Header("X-Content-Type-Options", "nosniff")
+		if t := upload.TypeByExt(path.Ext(c.Request.URL.Path)); t == nil || !t.IsImage() {
+			c.Header("Content-Disposition", "attachment")
+		}
+		c.Next()
+	}
+}
+
+// HideDotFiles 拒绝访问以 . 开头的文件和目录（如分片上传的临时数据）
+func HideDotFiles() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, segment := range strings.Split(c.Request.URL.Path, "/") {
+			if strings.HasPrefix(segment, ".") {
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
+		}
+		c.Next()
+	}
+}
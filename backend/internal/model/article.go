@@ -8,23 +8,58 @@ import (
 type Article struct {
 	ID         uint      `gorm:"primarykey" json:"id"`
 	Title      string    `gorm:"size:200;not null" json:"title"`              // 标题
+	Slug       string    `gorm:"size:200;index" json:"slug"`                  // URL 别名（同一翻译组的文章共用）
+	Language   string    `gorm:"size:10;default:'zh';index" json:"language"`  // 语言代码，如 zh、en
+	TranslationGroupID *uint `gorm:"index" json:"translation_group_id"`      // 翻译组 ID（取组内原文的文章 ID）
 	Content    string    `gorm:"type:longtext;not null" json:"content"`       // Markdown 内容
 	Summary    string    `gorm:"size:500" json:"summary"`                     // 摘要
 	CoverImg   string    `gorm:"size:500" json:"cover_img"`                   // 封面图
 	CategoryID *uint     `gorm:"index" json:"category_id"`                    // 分类 ID（外键）
+	AuthorID   *uint     `gorm:"index" json:"author_id"`                      // 作者 ID（外键）
 	Views      int       `gorm:"default:0" json:"views"`                      // 浏览量
 	Status     int8      `gorm:"default:0;index" json:"status"`               // 0=草稿 1=已发布
 	IsTop      bool      `gorm:"default:false" json:"is_top"`                 // 是否置顶
+	Password   string    `gorm:"size:100" json:"-"`                           // 访问密码（bcrypt 哈希，不对外输出）
+	IsProtected bool     `gorm:"default:false;index" json:"is_protected"`     // 是否加密文章
+	Metadata   JSONMap   `gorm:"type:json" json:"metadata"`                   // 自定义字段（按 CustomField 定义校验）
 	CreatedAt  time.Time `json:"created_at"`                                  // 创建时间
 	UpdatedAt  time. Time `json:"updated_at"`                                  // 更新时间
 	// 所属分类（多对一）
 	Category *Category `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
+
+	// 分类路径（从顶级分类到所属分类，仅详情返回，不入库）
+	Breadcrumbs []CategoryBreadcrumb `gorm:"-" json:"breadcrumbs,omitempty"`
+
+	// 作者（多对一）
+	Author *Author `gorm:"foreignKey:AuthorID" json:"author,omitempty"`
 	
 	// 关联标签（多对多）
 	Tags []Tag `gorm:"many2many:article_tags;" json:"tags,omitempty"`
+
+	// 其他语言版本（不入库）
+	Translations []ArticleTranslation `gorm:"-" json:"translations,omitempty"`
+
+	// 表态统计（表态类型 -> 数量，不入库）
+	Reactions map[string]int64 `gorm:"-" json:"reactions"`
 }
 
 // TableName 指定表名
 func (Article) TableName() string {
 	return "articles"
-}
\ No newline at end of file
+}
+
+// ArticleTranslation 文章的某个语言版本
+type ArticleTranslation struct {
+	ID       uint   `json:"id"`
+	Language string `json:"language"`
+	Title    string `json:"title"`
+	Slug     string `json:"slug"`
+}
+
+// TranslationGroup 返回文章所属的翻译组 ID（未关联翻译时为文章自身 ID）
+func (a *Article) TranslationGroup() uint {
+	if a.TranslationGroupID != nil {
+		return *a.TranslationGroupID
+	}
+	return a.ID
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// ArticleReferrerDaily 文章每日来源统计（来源域名 + 推广参数）
+type ArticleReferrerDaily struct {
+	ID          uint      `gorm:"primarykey" json:"id"`
+	ArticleID   uint      `gorm:"not null;uniqueIndex:idx_article_referrer,priority:1" json:"article_id"`            // 文章 ID
+	Date        time.Time `gorm:"type:date;not null;uniqueIndex:idx_article_referrer,priority:2;index" json:"date"`  // 日期
+	Referrer    string    `gorm:"size:100;not null;uniqueIndex:idx_article_referrer,priority:3" json:"referrer"`     // 来源域名（direct 表示直接访问）
+	UTMSource   string    `gorm:"size:50;not null;uniqueIndex:idx_article_referrer,priority:4" json:"utm_source"`    // utm_source
+	UTMMedium   string    `gorm:"size:50;not null;uniqueIndex:idx_article_referrer,priority:5" json:"utm_medium"`    // utm_medium
+	UTMCampaign string    `gorm:"size:100;not null;uniqueIndex:idx_article_referrer,priority:6" json:"utm_campaign"` // utm_campaign
+	Views       int       `gorm:"default:0" json:"views"`                                                            // 当日浏览量
+}
+
+// TableName 指定表名
+func (ArticleReferrerDaily) TableName() string {
+	return "article_referrer_daily"
+}
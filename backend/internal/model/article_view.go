@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// ArticleViewDaily 文章每日浏览量
+type ArticleViewDaily struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	ArticleID uint      `gorm:"not null;uniqueIndex:idx_article_date,priority:1" json:"article_id"`           // 文章 ID
+	Date      time.Time `gorm:"type:date;not null;uniqueIndex:idx_article_date,priority:2;index" json:"date"` // 日期
+	Views     int       `gorm:"default:0" json:"views"`                                                       // 当日浏览量
+}
+
+// TableName 指定表名
+func (ArticleViewDaily) TableName() string {
+	return "article_view_daily"
+}
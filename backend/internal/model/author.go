@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// Author 作者模型
+type Author struct {
+	ID          uint      `gorm:"primarykey" json:"id"`
+	Username    string    `gorm:"size:50;not null;unique" json:"-"` // 登录名（唯一，只在管理接口中返回）
+	Password    string    `gorm:"size:100;not null" json:"-"`       // 登录密钥（bcrypt 哈希，不对外输出）
+	Name        string    `gorm:"size:50;not null" json:"name"`     // 显示名称
+	Bio         string    `gorm:"size:500" json:"bio"`              // 个人简介
+	Avatar      string    `gorm:"size:500" json:"avatar"`           // 头像
+	SocialLinks StringMap `gorm:"type:json" json:"social_links"`    // 社交链接（如 github -> URL）
+	CreatedAt   time.Time `json:"created_at"`
+
+	// 关联：一个作者有多篇文章
+	Articles []Article `gorm:"foreignKey:AuthorID" json:"articles,omitempty"`
+}
+
+// TableName 指定表名
+func (Author) TableName() string {
+	return "authors"
+}
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// BrokenLink 文章或页面中检查失败的链接（每次检查后整体替换）
+type BrokenLink struct {
+	ID         uint      `gorm:"primarykey" json:"id"`
+	ArticleID  uint      `gorm:"not null;default:0;index" json:"article_id,omitempty"` // 所属文章（页面中的链接为 0）
+	PageID     uint      `gorm:"not null;default:0;index" json:"page_id,omitempty"`    // 所属页面（文章中的链接为 0）
+	URL        string    `gorm:"size:2048;not null" json:"url"`
+	Kind       string    `gorm:"size:10;not null" json:"kind"`  // link / image
+	Internal   bool      `gorm:"default:false" json:"internal"` // 是否站内链接
+	StatusCode int       `gorm:"default:0" json:"status_code"`  // HTTP 状态码（请求失败时为 0）
+	Error      string    `gorm:"size:255" json:"error"`         // 失败原因
+	CheckedAt  time.Time `gorm:"not null" json:"checked_at"`
+
+	// 所属文章或页面的标题（查询时联表读取，不建外键：检查结果只是快照，文章或页面删除时一并清理）
+	Title string `gorm:"->;-:migration" json:"-"`
+}
+
+// TableName 指定表名
+func (BrokenLink) TableName() string {
+	return "broken_links"
+}
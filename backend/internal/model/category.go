@@ -7,10 +7,26 @@ type Category struct {
 	ID          uint      `gorm:"primarykey" json:"id"`
 	Name        string    `gorm:"size:50;not null;unique" json:"name"`        // 分类名称（唯一）
 	Description string    `gorm:"size:200" json:"description"`                // 分类描述
+	ParentID    *uint     `gorm:"index" json:"parent_id"`                      // 父分类 ID（为空表示顶级分类）
+	SortOrder   int       `gorm:"default:0" json:"sort_order"`                 // 同级排序（越小越靠前）
+	Slug        *string   `gorm:"size:100;uniqueIndex:idx_categories_slug_unique" json:"slug"` // URL 别名（可选，唯一；未设置时为 NULL）
+	SEOTitle    string    `gorm:"size:200" json:"seo_title"`                   // SEO 标题（为空时使用名称）
+	MetaDescription string `gorm:"size:300" json:"meta_description"`          // 搜索引擎描述
+	CoverImg    string    `gorm:"size:500" json:"cover_img"`                   // 封面图
+	Intro       string    `gorm:"type:text" json:"intro"`                      // 分类介绍（Markdown）
 	CreatedAt   time.Time `json:"created_at"`
 	
 	// 关联：一个分类有多篇文章
 	Articles []Article `gorm:"foreignKey:CategoryID" json:"articles,omitempty"`
+
+	// 子分类（构建分类树时填充，不入库）
+	Children []*Category `gorm:"-" json:"children,omitempty"`
+}
+
+// CategoryBreadcrumb 分类路径中的一级（从顶级分类到当前分类）
+type CategoryBreadcrumb struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
 }
 
 // TableName 指定表名
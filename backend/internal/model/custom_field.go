@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+// 自定义字段类型
+const (
+	CustomFieldString  = "string"  // 文本
+	CustomFieldNumber  = "number"  // 数字
+	CustomFieldBoolean = "boolean" // 布尔
+	CustomFieldURL     = "url"     // 链接
+	CustomFieldSelect  = "select"  // 单选（取值见 Options）
+)
+
+// CustomField 文章自定义字段定义
+type CustomField struct {
+	ID        uint       `gorm:"primarykey" json:"id"`
+	Name      string     `gorm:"size:50;not null;unique" json:"name"` // 字段名（元数据中的 key，创建后不可修改）
+	Label     string     `gorm:"size:50" json:"label"`                // 显示名称
+	Type      string     `gorm:"size:20;not null" json:"type"`        // 字段类型：string/number/boolean/url/select
+	Required  bool       `gorm:"default:false" json:"required"`       // 是否必填
+	Options   StringList `gorm:"type:json" json:"options"`            // 可选值（select 类型）
+	Pattern   string     `gorm:"size:200" json:"pattern"`             // 正则校验（string 类型，可选）
+	MaxLength int        `gorm:"default:0" json:"max_length"`         // 最大长度（string 类型，0 表示不限制）
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TableName 指定表名
+func (CustomField) TableName() string {
+	return "custom_fields"
+}
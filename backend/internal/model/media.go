@@ -0,0 +1,90 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Media 媒体文件模型（相同内容只记录一条，RefCount 记录上传次数）
+type Media struct {
+	ID         uint          `gorm:"primarykey" json:"id"`
+	Path       string        `gorm:"size:255;not null;unique" json:"path"` // 访问路径（/uploads/...）
+	FileName   string        `gorm:"size:255" json:"file_name"`            // 原始文件名
+	Size       int64         `gorm:"not null" json:"size"`                 // 文件大小（字节）
+	MimeType   string        `gorm:"size:100;index" json:"mime_type"`
+	Width      int           `gorm:"default:0" json:"width"`              // 图片宽度
+	Height     int           `gorm:"default:0" json:"height"`             // 图片高度
+	Hash       string        `gorm:"size:64;index" json:"hash"`           // 上传内容的 SHA-256
+	RefCount   int           `gorm:"not null;default:1" json:"ref_count"` // 引用计数（同一内容被上传的次数）
+	Downloads  int64         `gorm:"not null;default:0" json:"downloads"` // 通过下载地址下载的次数
+	Variants   MediaVariants `gorm:"type:json" json:"variants"`           // 响应式版本和缩略图
+	UploaderID *uint         `gorm:"index" json:"uploader_id"`            // 上传者（共享密钥登录时为空）
+	UploadedAt time.Time     `gorm:"not null;index" json:"uploaded_at"`
+
+	DownloadURL string           `gorm:"-" json:"download_url,omitempty"` // 附件下载地址（非图片文件）
+	References  []MediaReference `gorm:"-" json:"references"`             // 引用该文件的内容（实时扫描得出）
+}
+
+// MediaVariant 图片的衍生版本
+type MediaVariant struct {
+	Name   string `json:"name"` // 版本名称（如 480w、thumb）
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// MediaVariants 衍生版本列表（JSON 存储）
+type MediaVariants []MediaVariant
+
+// Value 实现 driver.Valuer 接口
+func (v MediaVariants) Value() (driver.Value, error) {
+	if v == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+// Scan 实现 sql.Scanner 接口
+func (v *MediaVariants) Scan(value interface{}) error {
+	var b []byte
+	switch val := value.(type) {
+	case nil:
+		*v = MediaVariants{}
+		return nil
+	case []byte:
+		b = val
+	case string:
+		b = []byte(val)
+	default:
+		return errors.New("MediaVariants: 不支持的数据类型")
+	}
+	if len(b) == 0 {
+		*v = MediaVariants{}
+		return nil
+	}
+	return json.Unmarshal(b, v)
+}
+
+// URLs 返回所有衍生版本的访问路径
+func (v MediaVariants) URLs() []string {
+	urls := make([]string, 0, len(v))
+	for _, variant := range v {
+		urls = append(urls, variant.URL)
+	}
+	return urls
+}
+
+// MediaReference 引用媒体文件的内容
+type MediaReference struct {
+	Type  string `json:"type"` // article / page / author
+	ID    uint   `json:"id"`
+	Title string `json:"title"`
+}
+
+// TableName 指定表名
+func (Media) TableName() string {
+	return "media"
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// Page 独立页面模型（如“关于”“项目”“友链”，不属于博客文章）
+type Page struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	Title     string    `gorm:"size:200;not null" json:"title"`        // 标题
+	Slug      string    `gorm:"size:200;not null;unique" json:"slug"`  // URL 别名（唯一）
+	Content   string    `gorm:"type:longtext;not null" json:"content"` // Markdown 内容
+	MenuOrder int       `gorm:"default:0;index" json:"menu_order"`     // 菜单排序（越小越靠前）
+	Status    int8      `gorm:"default:0;index" json:"status"`         // 0=草稿 1=已发布
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (Page) TableName() string {
+	return "pages"
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// Reaction 文章表态（点赞、emoji）
+type Reaction struct {
+	ID          uint      `gorm:"primarykey" json:"id"`
+	ArticleID   uint      `gorm:"not null;uniqueIndex:idx_reaction_unique,priority:1" json:"article_id"`   // 文章 ID
+	Type        string    `gorm:"size:32;not null;uniqueIndex:idx_reaction_unique,priority:2" json:"type"` // 表态类型（emoji）
+	Fingerprint string    `gorm:"size:64;not null;uniqueIndex:idx_reaction_unique,priority:3" json:"-"`    // 访客指纹（Cookie + IP 哈希）
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// TableName 指定表名
+func (Reaction) TableName() string {
+	return "reactions"
+}
@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// StorageObject 上传存储中登记的文件（对象键唯一，用于多实例共享的存储用量统计）
+type StorageObject struct {
+	ObjectKey string    `gorm:"primaryKey;size:255" json:"object_key"`
+	Size      int64     `gorm:"not null" json:"size"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName 指定表名
+func (StorageObject) TableName() string {
+	return "storage_objects"
+}
+
+// StorageUsage 存储用量汇总（只有 ID 为 1 的一行，占用空间时按条件更新以保证不超过配额）
+type StorageUsage struct {
+	ID    uint  `gorm:"primarykey" json:"id"`
+	Bytes int64 `gorm:"not null;default:0" json:"bytes"`
+	Files int64 `gorm:"not null;default:0" json:"files"`
+}
+
+// TableName 指定表名
+func (StorageUsage) TableName() string {
+	return "storage_usages"
+}
@@ -6,10 +6,18 @@ import "time"
 type Tag struct {
 	ID        uint      `gorm:"primarykey" json:"id"`
 	Name      string    `gorm:"size:50;not null;unique" json:"name"` // 标签名称（唯一）
+	Slug      *string   `gorm:"size:100;uniqueIndex:idx_tags_slug_unique" json:"slug"` // URL 别名（可选，唯一；未设置时为 NULL）
+	SEOTitle  string    `gorm:"size:200" json:"seo_title"`            // SEO 标题（为空时使用名称）
+	MetaDescription string `gorm:"size:300" json:"meta_description"` // 搜索引擎描述
+	CoverImg  string    `gorm:"size:500" json:"cover_img"`            // 封面图
+	Intro     string    `gorm:"type:text" json:"intro"`               // 标签介绍（Markdown）
 	CreatedAt time.Time `json:"created_at"`
 	
 	// 关联：一个标签对应多篇文章（多对多）
 	Articles []Article `gorm:"many2many:article_tags;" json:"articles,omitempty"`
+	
+	// 别名：按名称查找时解析到该标签的同义词（删除标签时一并删除）
+	Aliases []TagAlias `gorm:"foreignKey:TagID;constraint:OnDelete:CASCADE" json:"aliases,omitempty"`
 }
 
 // TableName 指定表名
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// TagAlias 标签别名（同义词），按名称查找标签时解析为对应的标准标签
+type TagAlias struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	TagID     uint      `gorm:"not null;index" json:"tag_id"`         // 标准标签 ID
+	Alias     string    `gorm:"size:50;not null;unique" json:"alias"` // 别名（唯一）
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName 指定表名
+func (TagAlias) TableName() string {
+	return "tag_aliases"
+}
@@ -0,0 +1,106 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+// StringMap 以 JSON 格式存储的字符串键值对
+type StringMap map[string]string
+
+// Value 实现 driver.Valuer 接口
+func (m StringMap) Value() (driver.Value, error) {
+	if m == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(m)
+	return string(b), err
+}
+
+// Scan 实现 sql.Scanner 接口
+func (m *StringMap) Scan(value interface{}) error {
+	var b []byte
+	switch v := value.(type) {
+	case nil:
+		*m = StringMap{}
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return errors.New("StringMap: 不支持的数据类型")
+	}
+	if len(b) == 0 {
+		*m = StringMap{}
+		return nil
+	}
+	return json.Unmarshal(b, m)
+}
+
+// JSONMap 以 JSON 格式存储的任意键值对
+type JSONMap map[string]interface{}
+
+// Value 实现 driver.Valuer 接口
+func (m JSONMap) Value() (driver.Value, error) {
+	if m == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(m)
+	return string(b), err
+}
+
+// Scan 实现 sql.Scanner 接口
+func (m *JSONMap) Scan(value interface{}) error {
+	var b []byte
+	switch v := value.(type) {
+	case nil:
+		*m = JSONMap{}
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return errors.New("JSONMap: 不支持的数据类型")
+	}
+	if len(b) == 0 {
+		*m = JSONMap{}
+		return nil
+	}
+	return json.Unmarshal(b, m)
+}
+
+// StringList 以 JSON 数组格式存储的字符串列表
+type StringList []string
+
+// Value 实现 driver.Valuer 接口
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(l)
+	return string(b), err
+}
+
+// Scan 实现 sql.Scanner 接口
+func (l *StringList) Scan(value interface{}) error {
+	var b []byte
+	switch v := value.(type) {
+	case nil:
+		*l = StringList{}
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return errors.New("StringList: 不支持的数据类型")
+	}
+	if len(b) == 0 {
+		*l = StringList{}
+		return nil
+	}
+	return json.Unmarshal(b, l)
+}
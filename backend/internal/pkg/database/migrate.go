@@ -9,9 +9,28 @@ import (
 func AutoMigrate() error {
 	models := []interface{}{
 		&model.Category{},
+		&model.Author{},
 		&model.Tag{},
+		&model.TagAlias{},
 		&model.Article{},
 		&model.Comment{},  
+		&model.ArticleViewDaily{},
+		&model.ArticleReferrerDaily{},
+		&model.Reaction{},
+		&model.CustomField{},
+		&model.Page{},
+		&model.BrokenLink{},
+		&model.Media{},
+		&model.StorageObject{},
+		&model.StorageUsage{},
+	}
+	
+	if err := migrateSlugs(); err != nil {
+		return fmt.Errorf("数据表迁移失败: %w", err)
+	}
+	
+	if err := migrateBrokenLinks(); err != nil {
+		return fmt.Errorf("数据表迁移失败: %w", err)
 	}
 	
 	if err := DB.AutoMigrate(models...); err != nil {
@@ -20,4 +39,32 @@ func AutoMigrate() error {
 	
 	fmt.Println("✅ 数据表迁移成功")
 	return nil
+}
+
+// migrateSlugs 分类和标签的 URL 别名改为唯一索引前：空别名改为 NULL（唯一索引允许多个 NULL），并删除原来的普通索引
+func migrateSlugs() error {
+	for table, m := range map[string]interface{}{"categories": &model.Category{}, "tags": &model.Tag{}} {
+		// 旧版本数据库可能还没有 slug 列（由 AutoMigrate 添加，添加时已是唯一索引，无需处理）
+		if !DB.Migrator().HasTable(m) || !DB.Migrator().HasColumn(m, "slug") {
+			continue
+		}
+		if err := DB.Model(m).Where("slug = ?", "").Update("slug", nil).Error; err != nil {
+			return err
+		}
+		if index := "idx_" + table + "_slug"; DB.Migrator().HasIndex(m, index) {
+			if err := DB.Migrator().DropIndex(m, index); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// migrateBrokenLinks 死链记录不再关联文章外键：删除旧版本创建的外键约束，否则删除文章时会失败
+func migrateBrokenLinks() error {
+	m := &model.BrokenLink{}
+	if constraint := "fk_broken_links_article"; DB.Migrator().HasTable(m) && DB.Migrator().HasConstraint(m, constraint) {
+		return DB.Migrator().DropConstraint(m, constraint)
+	}
+	return nil
 }
\ No newline at end of file
@@ -0,0 +1,185 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"sort"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+const defaultQuality = 85
+
+// Options 图片处理选项
+type Options struct {
+	Widths      []int // 响应式图片宽度（只生成小于原图宽度的版本）
+	ThumbWidth  int   // 封面缩略图宽度（0 表示不生成）
+	ThumbHeight int   // 封面缩略图高度（0 表示不生成）
+	Quality     int   // JPEG 编码质量（1-100，0 使用默认值）
+}
+
+// Output 处理后的一张图片
+type Output struct {
+	Data   []byte
+	Ext    string // 扩展名（.jpg / .png / .webp）
+	Width  int
+	Height int
+}
+
+// Result 图片处理结果
+type Result struct {
+	Original  Output   // 去除元数据后的原图
+	Variants  []Output // 响应式版本（按宽度升序）
+	Thumbnail *Output  // 封面缩略图
+}
+
+// Supported 判断格式是否需要处理（GIF 可能是动图，保持原样）
+func Supported(format string) bool {
+	return format == "jpeg" || format == "png" || format == "webp"
+}
+
+// Process 处理图片：按 EXIF 方向摆正并重新编码以去除 EXIF/GPS 等元数据，
+// 再生成各宽度版本和封面缩略图。format 为 image 包中的解码器名称
+func Process(data []byte, format string, opts Options) (*Result, error) {
+	if !Supported(format) {
+		return nil, fmt.Errorf("不支持处理的图片格式: %s", format)
+	}
+	if opts.Quality <= 0 || opts.Quality > 100 {
+		opts.Quality = defaultQuality
+	}
+
+	// 1. 解码并按 EXIF 方向摆正
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.New("图片解码失败")
+	}
+	if format == "jpeg" {
+		src = applyOrientation(src, jpegOrientation(data))
+	}
+
+	// 2. 原图：JPEG/PNG 重新编码；WebP 没有纯 Go 编码器，直接删除元数据块
+	result := &Result{}
+	bounds := src.Bounds()
+	if format == "webp" {
+		stripped, err := stripWebPMetadata(data)
+		if err != nil {
+			return nil, err
+		}
+		result.Original = Output{Data: stripped, Ext: ".webp", Width: bounds.Dx(), Height: bounds.Dy()}
+	} else {
+		original, err := encode(src, format, opts.Quality)
+		if err != nil {
+			return nil, err
+		}
+		result.Original = *original
+	}
+
+	// 3. 衍生图片的编码格式：WebP 按是否透明选择 PNG 或 JPEG
+	variantFormat := format
+	if format == "webp" {
+		variantFormat = "jpeg"
+		if !isOpaque(src) {
+			variantFormat = "png"
+		}
+	}
+
+	// 4. 各宽度版本（不放大）
+	for _, width := range sortedWidths(opts.Widths) {
+		if width >= bounds.Dx() {
+			continue
+		}
+		height := bounds.Dy() * width / bounds.Dx()
+		if height < 1 {
+			height = 1
+		}
+		variant, err := encode(resize(src, src.Bounds(), width, height), variantFormat, opts.Quality)
+		if err != nil {
+			return nil, err
+		}
+		result.Variants = append(result.Variants, *variant)
+	}
+
+	// 5. 封面缩略图（居中裁剪到目标比例）
+	if opts.ThumbWidth > 0 && opts.ThumbHeight > 0 {
+		crop := coverCrop(bounds, opts.ThumbWidth, opts.ThumbHeight)
+		thumb, err := encode(resize(src, crop, opts.ThumbWidth, opts.ThumbHeight), variantFormat, opts.Quality)
+		if err != nil {
+			return nil, err
+		}
+		result.Thumbnail = thumb
+	}
+
+	return result, nil
+}
+
+// encode 编码图片
+func encode(img image.Image, format string, quality int) (*Output, error) {
+	var buf bytes.Buffer
+	var ext string
+	switch format {
+	case "jpeg":
+		ext = ".jpg"
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+			return nil, fmt.Errorf("图片编码失败: %v", err)
+		}
+	case "png":
+		ext = ".png"
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, fmt.Errorf("图片编码失败: %v", err)
+		}
+	default:
+		return nil, fmt.Errorf("不支持编码的图片格式: %s", format)
+	}
+
+	bounds := img.Bounds()
+	return &Output{Data: buf.Bytes(), Ext: ext, Width: bounds.Dx(), Height: bounds.Dy()}, nil
+}
+
+// resize 把 src 中的 rect 区域缩放到 width x height
+func resize(src image.Image, rect image.Rectangle, width, height int) image.Image {
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), src, rect, draw.Src, nil)
+	return dst
+}
+
+// coverCrop 计算居中裁剪到 width:height 比例的区域
+func coverCrop(bounds image.Rectangle, width, height int) image.Rectangle {
+	w, h := bounds.Dx(), bounds.Dy()
+	if w*height > h*width {
+		// 原图更宽，裁掉左右
+		cropW := h * width / height
+		x := bounds.Min.X + (w-cropW)/2
+		return image.Rect(x, bounds.Min.Y, x+cropW, bounds.Max.Y)
+	}
+	// 原图更高，裁掉上下
+	cropH := w * height / width
+	y := bounds.Min.Y + (h-cropH)/2
+	return image.Rect(bounds.Min.X, y, bounds.Max.X, y+cropH)
+}
+
+// isOpaque 判断图片是否完全不透明
+func isOpaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+	return false
+}
+
+// sortedWidths 去重并升序排列宽度
+func sortedWidths(widths []int) []int {
+	seen := make(map[int]bool)
+	var result []int
+	for _, w := range widths {
+		if w > 0 && !seen[w] {
+			seen[w] = true
+			result = append(result, w)
+		}
+	}
+	sort.Ints(result)
+	return result
+}
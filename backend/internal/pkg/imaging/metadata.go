@@ -0,0 +1,143 @@
+package imaging
+
+import (
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/draw"
+)
+
+// jpegOrientation 读取 JPEG 中 EXIF 的方向标签（0x0112），没有时返回 1
+func jpegOrientation(data []byte) int {
+	off := 2
+	for off+4 <= len(data) && data[off] == 0xFF {
+		marker := data[off+1]
+		length := int(binary.BigEndian.Uint16(data[off+2:]))
+		if marker == 0xDA || length < 2 || off+2+length > len(data) {
+			break
+		}
+		segment := data[off+4 : off+2+length]
+		if marker == 0xE1 && len(segment) > 6 && string(segment[:6]) == "Exif\x00\x00" {
+			return tiffOrientation(segment[6:])
+		}
+		off += 2 + length
+	}
+	return 1
+}
+
+// tiffOrientation 从 TIFF 结构的 IFD0 中读取方向标签
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd+2 > len(tiff) {
+		return 1
+	}
+	count := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < count; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			break
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			if v := int(order.Uint16(tiff[entry+8:])); v >= 1 && v <= 8 {
+				return v
+			}
+			break
+		}
+	}
+	return 1
+}
+
+// applyOrientation 按 EXIF 方向旋转/翻转图片
+func applyOrientation(src image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return src
+	}
+
+	b := src.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), src, b.Min, draw.Src)
+	w, h := b.Dx(), b.Dy()
+
+	// 5-8 需要交换宽高
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // 水平翻转
+				dx, dy = w-1-x, y
+			case 3: // 旋转 180°
+				dx, dy = w-1-x, h-1-y
+			case 4: // 垂直翻转
+				dx, dy = x, h-1-y
+			case 5: // 沿左上-右下对角线翻转
+				dx, dy = y, x
+			case 6: // 顺时针旋转 90°
+				dx, dy = h-1-y, x
+			case 7: // 沿右上-左下对角线翻转
+				dx, dy = h-1-y, w-1-x
+			case 8: // 逆时针旋转 90°
+				dx, dy = y, w-1-x
+			}
+			i := rgba.PixOffset(x, y)
+			j := dst.PixOffset(dx, dy)
+			copy(dst.Pix[j:j+4], rgba.Pix[i:i+4])
+		}
+	}
+	return dst
+}
+
+// stripWebPMetadata 删除 WebP 中的 EXIF 和 XMP 数据块，并清除 VP8X 中对应的标志位
+func stripWebPMetadata(data []byte) ([]byte, error) {
+	if len(data) < 12 {
+		return nil, errors.New("WebP 文件结构损坏")
+	}
+
+	out := make([]byte, 12, len(data))
+	copy(out, data[:12])
+
+	off := 12
+	for off+8 <= len(data) {
+		fourCC := string(data[off : off+4])
+		size := int(binary.LittleEndian.Uint32(data[off+4:]))
+		end := off + 8 + size + size%2 // 数据块按偶数字节对齐
+		if end > len(data) {
+			return nil, errors.New("WebP 文件结构损坏")
+		}
+
+		switch fourCC {
+		case "EXIF", "XMP ":
+			// 丢弃
+		case "VP8X":
+			chunk := append([]byte{}, data[off:end]...)
+			if size > 0 {
+				chunk[8] &^= 0x08 | 0x04 // EXIF、XMP 标志位
+			}
+			out = append(out, chunk...)
+		default:
+			out = append(out, data[off:end]...)
+		}
+		off = end
+	}
+
+	binary.LittleEndian.PutUint32(out[4:8], uint32(len(out)-8))
+	return out, nil
+}
@@ -0,0 +1,78 @@
+package limiter
+
+import (
+	"sync"
+	"time"
+)
+
+// FailureLimiter 失败次数限制器（按 key 统计时间窗口内的失败次数，用于防暴力破解）
+type FailureLimiter struct {
+	maxFailures int           // 窗口内允许的最大失败次数
+	window      time.Duration // 统计窗口
+	records     map[string]*failureRecord
+	mu          sync.Mutex
+}
+
+// failureRecord 单个 key 的失败记录
+type failureRecord struct {
+	count   int
+	resetAt time.Time // 窗口结束时间
+}
+
+// NewFailureLimiter 创建失败次数限制器
+func NewFailureLimiter(maxFailures int, window time.Duration) *FailureLimiter {
+	l := &FailureLimiter{
+		maxFailures: maxFailures,
+		window:      window,
+		records:     make(map[string]*failureRecord),
+	}
+	// 启动清理过期记录的协程
+	go l.cleanExpired()
+	return l
+}
+
+// Reserve 原子地检查并占用一次尝试机会（先按失败计数），已达上限时返回 false
+// 用于校验耗时较长的场景：并发请求不会在记录失败前同时通过检查；校验成功后调用 Release 归还
+func (l *FailureLimiter) Reserve(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	record, exists := l.records[key]
+	if !exists || now.After(record.resetAt) {
+		l.records[key] = &failureRecord{count: 1, resetAt: now.Add(l.window)}
+		return true
+	}
+	if record.count >= l.maxFailures {
+		return false
+	}
+	record.count++
+	return true
+}
+
+// Release 归还一次 Reserve 占用的尝试机会（之前记录的失败次数保留）
+func (l *FailureLimiter) Release(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if record, exists := l.records[key]; exists && record.count > 0 {
+		record.count--
+	}
+}
+
+// cleanExpired 定期清理过期记录
+func (l *FailureLimiter) cleanExpired() {
+	ticker := time.NewTicker(10 * time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		l.mu.Lock()
+		now := time.Now()
+		for key, record := range l.records {
+			if now.After(record.resetAt) {
+				delete(l.records, key)
+			}
+		}
+		l.mu.Unlock()
+	}
+}
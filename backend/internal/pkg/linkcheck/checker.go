@@ -0,0 +1,128 @@
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultTimeout   = 10 * time.Second
+	defaultUserAgent = "my-blog-link-checker/1.0"
+	maxRedirects     = 10
+)
+
+// ErrInternalAddress 链接指向内网地址（拒绝请求，避免借死链检查探测内网服务）
+var ErrInternalAddress = errors.New("禁止访问内网地址")
+
+// sharedAddressSpace 运营商级 NAT 地址段（RFC 6598），云厂商常用作内网元数据服务地址
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// Checker 外部链接检查器
+type Checker struct {
+	client    *http.Client
+	userAgent string
+}
+
+// NewChecker 创建外部链接检查器（timeout 为 0、userAgent 为空时使用默认值）
+func NewChecker(timeout time.Duration, userAgent string) *Checker {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	if userAgent == "" {
+		userAgent = defaultUserAgent
+	}
+	// 在建立连接时检查解析后的 IP，重定向和 DNS 重绑定同样会被拦截；
+	// 不使用环境变量中的代理，否则检查的是代理地址而不是目标地址
+	dialer := &net.Dialer{Timeout: timeout, Control: refuseInternal}
+	transport := &http.Transport{
+		DialContext:         dialer.DialContext,
+		TLSHandshakeTimeout: timeout,
+		MaxIdleConns:        100,
+		IdleConnTimeout:     90 * time.Second,
+	}
+	return &Checker{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) >= maxRedirects {
+					return http.ErrUseLastResponse
+				}
+				return nil
+			},
+		},
+		userAgent: userAgent,
+	}
+}
+
+// Check 检查外部链接，返回最终的 HTTP 状态码
+// 先发送 HEAD 请求；对不支持 HEAD（或拒绝 HEAD）的站点再用只取首字节的 GET 重试
+func (c *Checker) Check(ctx context.Context, url string) (int, error) {
+	status, err := c.do(ctx, http.MethodHead, url)
+	if err == nil && !headUnsupported(status) {
+		return status, nil
+	}
+	return c.do(ctx, http.MethodGet, url)
+}
+
+// IsBroken 判断状态码是否表示链接失效（429 限流不视为失效）
+func IsBroken(status int) bool {
+	return status >= 400 && status != http.StatusTooManyRequests
+}
+
+// do 发送请求并返回状态码
+func (c *Checker) do(ctx context.Context, method, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	if method == http.MethodGet {
+		req.Header.Set("Range", "bytes=0-0")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1024))
+
+	return resp.StatusCode, nil
+}
+
+// refuseInternal 拒绝连接回环、私有、链路本地等内网地址
+func refuseInternal(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if isInternal(addrPort.Addr()) {
+		return ErrInternalAddress
+	}
+	return nil
+}
+
+// isInternal 判断 IP 是否属于内网地址
+func isInternal(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() ||
+		sharedAddressSpace.Contains(ip)
+}
+
+// headUnsupported 判断 HEAD 请求的结果是否需要改用 GET 重试
+func headUnsupported(status int) bool {
+	switch status {
+	case http.StatusMethodNotAllowed, http.StatusNotImplemented, http.StatusForbidden, http.StatusNotFound:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package linkcheck
+
+import (
+	"regexp"
+	"strings"
+)
+
+// 链接类型
+const (
+	KindLink  = "link"  // 普通链接
+	KindImage = "image" // 图片引用
+)
+
+// Link 从 Markdown 中提取的链接
+type Link struct {
+	URL  string
+	Kind string
+}
+
+var (
+	// 代码块和行内代码中的内容不是链接
+	fencedCodePattern = regexp.MustCompile("(?ms)^[ \t]*(```|~~~).*?^[ \t]*(```|~~~)[ \t]*$")
+	inlineCodePattern = regexp.MustCompile("`[^`\n]+`")
+
+	// [text](url "title") 和 ![alt](url "title")
+	inlineLinkPattern = regexp.MustCompile(`(!?)\[[^\[\]]*\]\(\s*<?([^\s()<>]+)>?(?:\s+(?:"[^"]*"|'[^']*'|\([^)]*\)))?\s*\)`)
+	// [![alt](img)](url)：外层链接
+	imageLinkPattern = regexp.MustCompile(`\[!\[[^\[\]]*\]\([^)]*\)\]\(\s*<?([^\s()<>]+)>?(?:\s+(?:"[^"]*"|'[^']*'|\([^)]*\)))?\s*\)`)
+	// [ref]: url "title"
+	referencePattern = regexp.MustCompile(`(?m)^[ ]{0,3}\[[^\]]+\]:\s*<?(\S+?)>?(?:\s+.*)?$`)
+	// <https://example.com>
+	autoLinkPattern = regexp.MustCompile(`<(https?://[^>\s]+)>`)
+	// 内嵌 HTML
+	htmlImagePattern = regexp.MustCompile(`(?i)<img\s[^>]*?src\s*=\s*["']([^"']+)["']`)
+	htmlLinkPattern  = regexp.MustCompile(`(?i)<a\s[^>]*?href\s*=\s*["']([^"']+)["']`)
+)
+
+// Extract 从 Markdown 中提取链接和图片引用（按出现顺序去重，忽略代码块）
+func Extract(markdown string) []Link {
+	// 1. 去掉代码
+	text := fencedCodePattern.ReplaceAllString(markdown, "")
+	text = inlineCodePattern.ReplaceAllString(text, "")
+
+	var links []Link
+	seen := make(map[Link]bool)
+	add := func(url, kind string) {
+		url = strings.TrimSpace(url)
+		if url == "" {
+			return
+		}
+		link := Link{URL: url, Kind: kind}
+		if seen[link] {
+			return
+		}
+		seen[link] = true
+		links = append(links, link)
+	}
+
+	// 2. Markdown 行内链接和图片
+	for _, match := range inlineLinkPattern.FindAllStringSubmatch(text, -1) {
+		if match[1] == "!" {
+			add(match[2], KindImage)
+		} else {
+			add(match[2], KindLink)
+		}
+	}
+
+	for _, match := range imageLinkPattern.FindAllStringSubmatch(text, -1) {
+		add(match[1], KindLink)
+	}
+
+	// 3. 引用式链接定义、自动链接
+	for _, match := range referencePattern.FindAllStringSubmatch(text, -1) {
+		add(match[1], KindLink)
+	}
+	for _, match := range autoLinkPattern.FindAllStringSubmatch(text, -1) {
+		add(match[1], KindLink)
+	}
+
+	// 4. 内嵌 HTML
+	for _, match := range htmlImagePattern.FindAllStringSubmatch(text, -1) {
+		add(match[1], KindImage)
+	}
+	for _, match := range htmlLinkPattern.FindAllStringSubmatch(text, -1) {
+		add(match[1], KindLink)
+	}
+
+	return links
+}
@@ -77,4 +77,22 @@ func PageSuccess(c *gin. Context, list interface{}, total int64, page, pageSize
 			PageSize: pageSize,
 		},
 	})
-}
\ No newline at end of file
+}
+
+// Forbidden 无权访问响应（403，可携带数据）
+func Forbidden(c *gin.Context, msg string, data interface{}) {
+	c.JSON(http.StatusForbidden, Response{
+		Code: http.StatusForbidden,
+		Msg:  msg,
+		Data: data,
+	})
+}
+
+// TooManyRequests 请求过于频繁响应（429）
+func TooManyRequests(c *gin.Context, msg string) {
+	c.JSON(http.StatusTooManyRequests, Response{
+		Code: http.StatusTooManyRequests,
+		Msg:  msg,
+		Data: nil,
+	})
+}
@@ -0,0 +1,23 @@
+package segment
+
+import "regexp"
+
+var (
+	fencedCodePattern = regexp.MustCompile("(?ms)^\\s*(```|~~~).*?^\\s*(```|~~~)\\s*$")
+	inlineCodePattern = regexp.MustCompile("`[^`\n]*`")
+	imagePattern      = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	linkPattern       = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	urlPattern        = regexp.MustCompile(`https?://\S+`)
+	htmlTagPattern    = regexp.MustCompile(`<[^>]+>`)
+)
+
+// PlainText 去除 Markdown 中的代码、链接地址和 HTML 标签，保留可分词的正文
+// 标题、列表、强调等标记符号会在分词时作为标点忽略
+func PlainText(markdown string) string {
+	text := fencedCodePattern.ReplaceAllString(markdown, "\n")
+	text = inlineCodePattern.ReplaceAllString(text, " ")
+	text = imagePattern.ReplaceAllString(text, "$1")
+	text = linkPattern.ReplaceAllString(text, "$1")
+	text = urlPattern.ReplaceAllString(text, " ")
+	return htmlTagPattern.ReplaceAllString(text, " ")
+}
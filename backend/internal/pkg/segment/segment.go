@@ -0,0 +1,254 @@
+package segment
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	maxWordAtoms = 8 // 词典词最多包含的字/单词数
+	minNGram     = 2 // 新词发现的最短长度（汉字数）
+	maxNGram     = 4 // 新词发现的最长长度（汉字数）
+)
+
+// Segmenter 中英文混合分词器
+// 英文按单词切分；中文按词典正向最大匹配，词典之外的汉字需通过 Discover 发现新词后才会输出
+type Segmenter struct {
+	dict map[string]bool
+}
+
+// New 创建分词器，words 为初始词典（如已有标签名称）
+func New(words ...string) *Segmenter {
+	s := &Segmenter{dict: make(map[string]bool)}
+	s.AddWords(words...)
+	return s
+}
+
+// AddWords 向词典添加词语
+func (s *Segmenter) AddWords(words ...string) {
+	for _, word := range words {
+		if key := Key(word); key != "" {
+			s.dict[key] = true
+		}
+	}
+}
+
+// Cut 对文本分词，返回规范化后的词语（英文小写，已去除停用词）
+func (s *Segmenter) Cut(text string) []string {
+	var words []string
+	s.walk(text, func(word string) {
+		words = append(words, word)
+	}, nil)
+	return words
+}
+
+// Discover 从文本中未被词典匹配的汉字片段里发现在文中重复出现至少 minCount 次的新词
+// 包含在更长新词中且出现次数相同的片段会被去除（如「并发编」与「并发编程」）
+func (s *Segmenter) Discover(text string, minCount int) []string {
+	// 1. 统计未匹配片段中的 n-gram
+	counts := make(map[string]int)
+	s.walk(text, nil, func(run []rune) {
+		for n := minNGram; n <= maxNGram; n++ {
+			for i := 0; i+n <= len(run); i++ {
+				if isStopRune(run[i]) || isStopRune(run[i+n-1]) {
+					continue
+				}
+				counts[string(run[i:i+n])]++
+			}
+		}
+	})
+
+	// 2. 保留重复出现的 n-gram，从长到短去除被包含的片段
+	var candidates []string
+	for gram, count := range counts {
+		if count >= minCount {
+			candidates = append(candidates, gram)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		li, lj := utf8.RuneCountInString(candidates[i]), utf8.RuneCountInString(candidates[j])
+		if li != lj {
+			return li > lj
+		}
+		return candidates[i] < candidates[j]
+	})
+	var words []string
+	for _, gram := range candidates {
+		covered := false
+		for _, word := range words {
+			if strings.Contains(word, gram) && counts[word] >= counts[gram] {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			words = append(words, gram)
+		}
+	}
+	return words
+}
+
+// walk 按词典切分文本：onWord 接收切分出的词语，onRun 接收未被词典匹配的连续汉字片段
+func (s *Segmenter) walk(text string, onWord func(string), onRun func([]rune)) {
+	for _, run := range atomize(text) {
+		var pending []rune
+		flush := func() {
+			if len(pending) >= minNGram && onRun != nil {
+				onRun(pending)
+			}
+			pending = nil
+		}
+
+		for i := 0; i < len(run); {
+			// 1. 词典最大匹配
+			matched, minLen := 0, 1
+			if run[i].han {
+				minLen = 2 // 单个汉字不作为词语
+			}
+			for n := min(len(run)-i, maxWordAtoms); n >= minLen; n-- {
+				if s.dict[join(run[i:i+n])] {
+					matched = n
+					break
+				}
+			}
+			if matched > 0 {
+				flush()
+				if onWord != nil {
+					onWord(join(run[i : i+matched]))
+				}
+				i += matched
+				continue
+			}
+
+			// 2. 未匹配的英文单词直接输出，汉字留待新词发现
+			if run[i].han {
+				pending = append(pending, []rune(run[i].text)[0])
+			} else {
+				flush()
+				if onWord != nil && isWord(run[i].text) {
+					onWord(run[i].text)
+				}
+			}
+			i++
+		}
+		flush()
+	}
+}
+
+// Key 返回词语的规范化形式（与 Cut 的输出一致），用于比较和词典查找
+func Key(word string) string {
+	var keys []string
+	for _, run := range atomize(word) {
+		keys = append(keys, join(run))
+	}
+	return strings.Join(keys, " ")
+}
+
+// atom 分词的最小单位：一个英文单词或一个汉字
+type atom struct {
+	text string
+	han  bool
+}
+
+// atomize 将文本拆分为若干片段，每个片段是不被标点隔开的连续字词
+// 空格、点号、连字符和下划线只分隔单词，不切断片段（以便匹配 "Node.js"、"machine learning" 等词）
+func atomize(text string) [][]atom {
+	var runs [][]atom
+	var run []atom
+	var word strings.Builder
+	endWord := func() {
+		if word.Len() > 0 {
+			run = append(run, atom{text: word.String()})
+			word.Reset()
+		}
+	}
+	endRun := func() {
+		endWord()
+		if len(run) > 0 {
+			runs = append(runs, run)
+			run = nil
+		}
+	}
+
+	for _, r := range strings.ToLower(text) {
+		r = toHalfWidth(r)
+		switch {
+		case unicode.Is(unicode.Han, r):
+			endWord()
+			run = append(run, atom{text: string(r), han: true})
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word.WriteRune(r)
+		case (r == '+' || r == '#') && word.Len() > 0: // C++、C#
+			word.WriteRune(r)
+		case r == ' ' || r == '\t' || r == '.' || r == '-' || r == '_':
+			endWord()
+		default:
+			endRun()
+		}
+	}
+	endRun()
+	return runs
+}
+
+// join 拼接片段中的字词（相邻英文单词之间用空格分隔）
+func join(atoms []atom) string {
+	var b strings.Builder
+	for i, a := range atoms {
+		if i > 0 && !a.han && !atoms[i-1].han {
+			b.WriteByte(' ')
+		}
+		b.WriteString(a.text)
+	}
+	return b.String()
+}
+
+// toHalfWidth 全角字符转半角
+func toHalfWidth(r rune) rune {
+	switch {
+	case r == '　':
+		return ' '
+	case r >= '！' && r <= '～':
+		return r - 0xFEE0
+	}
+	return r
+}
+
+// isWord 判断英文单词是否有意义（至少两个字符、不是纯数字、不是停用词）
+func isWord(word string) bool {
+	if len(word) < 2 || stopWords[word] {
+		return false
+	}
+	for _, r := range word {
+		if !unicode.IsDigit(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// isStopRune 判断汉字是否为虚词（新词不以这些字开头或结尾）
+func isStopRune(r rune) bool {
+	return strings.ContainsRune(stopRunes, r)
+}
+
+// stopRunes 中文虚词和常见无意义单字
+const stopRunes = "的了是在和与及或也就都而这那个一我你他她它们着过吗呢吧啊么之其该很更最又但若则把被让从"
+
+// stopWords 英文停用词
+var stopWords = map[string]bool{
+	"a": true, "an": true, "the": true, "and": true, "or": true, "but": true, "if": true, "then": true, "else": true,
+	"of": true, "to": true, "in": true, "on": true, "at": true, "by": true, "for": true, "with": true, "from": true,
+	"as": true, "is": true, "are": true, "was": true, "were": true, "be": true, "been": true, "being": true,
+	"it": true, "its": true, "this": true, "that": true, "these": true, "those": true, "there": true, "here": true,
+	"we": true, "you": true, "he": true, "she": true, "they": true, "i": true, "me": true, "my": true, "our": true,
+	"your": true, "their": true, "not": true, "no": true, "can": true, "will": true, "would": true, "should": true,
+	"could": true, "may": true, "do": true, "does": true, "did": true, "have": true, "has": true, "had": true,
+	"so": true, "than": true, "too": true, "very": true, "just": true, "also": true, "into": true, "about": true,
+	"what": true, "which": true, "who": true, "when": true, "where": true, "how": true, "why": true, "all": true,
+	"any": true, "some": true, "more": true, "most": true, "other": true, "such": true, "only": true, "own": true,
+	"same": true, "each": true, "both": true, "few": true, "out": true, "up": true, "down": true, "over": true,
+	"use": true, "using": true, "used": true, "get": true, "set": true, "new": true, "one": true, "two": true,
+	"http": true, "https": true, "www": true, "com": true,
+}
@@ -0,0 +1,74 @@
+package segment
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCut(t *testing.T) {
+	tests := []struct {
+		name string
+		dict []string
+		text string
+		want []string
+	}{
+		{"词典最大匹配", []string{"机器", "机器学习"}, "机器学习很有趣", []string{"机器学习"}},
+		{"未登录汉字不输出", nil, "今天天气不错", nil},
+		{"英文去除停用词", nil, "The Go language is great", []string{"go", "language", "great"}},
+		{"多词词典", []string{"Machine Learning"}, "Machine-Learning rocks", []string{"machine learning", "rocks"}},
+		{"点号连接的词", []string{"Node.js"}, "使用Node.js开发", []string{"node js"}},
+		{"C++ 和 C#", nil, "C++ and C#", []string{"c++", "c#"}},
+		{"全角字母", []string{"go"}, "ＧＯ语言", []string{"go"}},
+		{"跳过纯数字", nil, "2024 年 v2", []string{"v2"}},
+		{"标点切断片段", []string{"机器学习"}, "机器，学习", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := New(tt.dict...).Cut(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Cut(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	tests := []struct {
+		name     string
+		dict     []string
+		text     string
+		minCount int
+		want     []string
+	}{
+		{"去除被包含的片段", nil, "并发编程很难。并发编程需要练习。", 2, []string{"并发编程"}},
+		{"词典词不参与发现", []string{"编程"}, "并发编程很难。并发编程需要练习。", 2, []string{"并发"}},
+		{"出现次数不足", nil, "并发编程很难。并发编程需要练习。", 3, nil},
+		{"不以虚词开头或结尾", nil, "的模型和的模型", 2, []string{"模型"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := New(tt.dict...).Discover(tt.text, tt.minCount); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Discover(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		word string
+		want string
+	}{
+		{"  Machine   Learning ", "machine learning"},
+		{"Node.js", "node js"},
+		{"机器学习", "机器学习"},
+		{"ＡＩ", "ai"},
+		{"C++", "c++"},
+		{"Go语言", "go语言"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := Key(tt.word); got != tt.want {
+			t.Errorf("Key(%q) = %q, want %q", tt.word, got, tt.want)
+		}
+	}
+}
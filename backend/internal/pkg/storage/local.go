@@ -0,0 +1,191 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Local 本地磁盘存储
+type Local struct {
+	root    string // 存储目录
+	baseURL string // 访问地址前缀（如 /uploads/）
+}
+
+// NewLocal 创建本地磁盘存储
+func NewLocal(root, baseURL string) *Local {
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+	return &Local{root: root, baseURL: baseURL}
+}
+
+// Root 返回存储目录（用于静态文件服务）
+func (l *Local) Root() string {
+	return l.root
+}
+
+// Put 写入对象（先写临时文件再重命名，避免读到写了一半的文件）
+func (l *Local) Put(ctx context.Context, key string, body io.ReadSeeker, contentType string) error {
+	dst, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), os.ModePerm); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(dst), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), dst)
+}
+
+// Get 读取对象
+func (l *Local) Get(ctx context.Context, key string) (io.ReadCloser, *ObjectInfo, error) {
+	p, err := l.path(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, nil, err
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	if stat.IsDir() {
+		f.Close()
+		return nil, nil, fs.ErrNotExist
+	}
+	return f, l.info(key, stat), nil
+}
+
+// Stat 查询对象信息
+func (l *Local) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	p, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	stat, err := os.Stat(p)
+	if err != nil {
+		return nil, err
+	}
+	if stat.IsDir() {
+		return nil, fs.ErrNotExist
+	}
+	return l.info(key, stat), nil
+}
+
+// Delete 删除对象
+func (l *Local) Delete(ctx context.Context, key string) error {
+	p, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// List 遍历所有对象（跳过以 . 开头的目录和文件，如临时文件）
+func (l *Local) List(ctx context.Context, fn func(ObjectInfo) error) error {
+	err := filepath.WalkDir(l.root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if p != l.root && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(l.root, p)
+		if err != nil {
+			return err
+		}
+		stat, err := d.Info()
+		if err != nil {
+			return err
+		}
+		return fn(*l.info(filepath.ToSlash(rel), stat))
+	})
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// URL 返回访问地址
+func (l *Local) URL(key string) string {
+	return l.baseURL + key
+}
+
+// KeyFromURL 从访问地址解析对象键
+func (l *Local) KeyFromURL(url string) (string, bool) {
+	if !strings.HasPrefix(url, l.baseURL) {
+		return "", false
+	}
+	key, err := cleanKey(strings.TrimPrefix(url, l.baseURL))
+	if err != nil {
+		return "", false
+	}
+	return key, true
+}
+
+// path 把对象键转换为磁盘路径（防止路径穿越到存储目录之外）
+func (l *Local) path(key string) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(l.root, filepath.FromSlash(key)), nil
+}
+
+// info 构造对象信息
+func (l *Local) info(key string, stat fs.FileInfo) *ObjectInfo {
+	return &ObjectInfo{
+		Key:         key,
+		Size:        stat.Size(),
+		ModTime:     stat.ModTime(),
+		ContentType: mime.TypeByExtension(path.Ext(key)),
+	}
+}
+
+// cleanKey 规范化对象键，拒绝空键和路径穿越
+func cleanKey(key string) (string, error) {
+	cleaned := strings.TrimPrefix(path.Clean("/"+key), "/")
+	if cleaned == "" || cleaned != strings.TrimPrefix(key, "/") {
+		return "", fs.ErrInvalid
+	}
+	return cleaned, nil
+}
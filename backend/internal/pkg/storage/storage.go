@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// ObjectInfo 存储对象信息
+type ObjectInfo struct {
+	Key         string    // 对象键（如 2024/01/02/xxx.jpg）
+	Size        int64     // 大小（字节）
+	ModTime     time.Time // 最后修改时间
+	ContentType string
+}
+
+// Storage 上传文件存储后端
+// 对象不存在时，Get/Stat 返回的错误满足 errors.Is(err, fs.ErrNotExist)
+type Storage interface {
+	// Put 写入对象（已存在时覆盖），body 可能需要读取多次（如先计算签名），因此要求可 Seek
+	Put(ctx context.Context, key string, body io.ReadSeeker, contentType string) error
+	// Get 读取对象，调用方负责关闭返回的 ReadCloser
+	Get(ctx context.Context, key string) (io.ReadCloser, *ObjectInfo, error)
+	// Stat 查询对象信息
+	Stat(ctx context.Context, key string) (*ObjectInfo, error)
+	// Delete 删除对象（不存在时不报错）
+	Delete(ctx context.Context, key string) error
+	// List 遍历所有对象
+	List(ctx context.Context, fn func(ObjectInfo) error) error
+	// URL 返回对象的访问地址
+	URL(key string) string
+	// KeyFromURL 从访问地址解析对象键，不属于该存储时返回 false
+	KeyFromURL(url string) (string, bool)
+}
@@ -0,0 +1,73 @@
+package upload
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// ThumbnailVariant 封面缩略图的版本名称
+const ThumbnailVariant = "thumb"
+
+// DownloadPrefix 附件下载地址前缀（由后端读取存储并以附件形式返回，同时统计下载次数）
+const DownloadPrefix = "/download/"
+
+// Variant 图片的衍生版本
+type Variant struct {
+	Name   string // 版本名称（如 480w、thumb）
+	URL    string
+	Width  int
+	Height int
+}
+
+// FileInfo 已保存文件的信息
+type FileInfo struct {
+	URL      string // 访问地址（由存储后端生成，如 /uploads/ab/cd/abcd....png）
+	Key      string // 存储对象键（如 ab/cd/abcd....png）
+	Name     string // 原始文件名
+	Size     int64  // 文件大小（字节）
+	MimeType string // 根据内容识别的 MIME 类型
+	Category string // 文件类别（image/document/archive/audio）
+	Width    int    // 图片宽度（非图片为 0）
+	Height   int    // 图片高度（非图片为 0）
+	Hash     string // 上传内容的 SHA-256（十六进制），同时决定存储路径
+	Existing bool   // 相同内容的文件已存在（重复上传），未写入新文件
+
+	DownloadURL string // 附件下载地址（非图片文件）
+
+	Variants []Variant // 图片的响应式版本和缩略图
+}
+
+// Remove 删除上传文件并释放配额（文件不存在时不报错）
+func Remove(ctx context.Context, config *UploadConfig, url string) error {
+	key, ok := config.Storage.KeyFromURL(url)
+	if !ok {
+		return errors.New("不是上传文件地址")
+	}
+	if err := config.Storage.Delete(ctx, key); err != nil {
+		return err
+	}
+	return config.release(ctx, key)
+}
+
+// Link 返回插入内容时使用的地址：附件为下载地址，图片为文件地址
+func (f *FileInfo) Link() string {
+	if f.DownloadURL != "" {
+		return f.DownloadURL
+	}
+	return f.URL
+}
+
+// DownloadURL 返回存储对象的下载地址
+func DownloadURL(key string) string {
+	return DownloadPrefix + key
+}
+
+// KeyFromDownloadURL 从下载地址中解析存储对象键
+func KeyFromDownloadURL(url string) (string, bool) {
+	key, ok := strings.CutPrefix(url, DownloadPrefix)
+	if !ok || key == "" {
+		return "", false
+	}
+	return key, true
+}
@@ -0,0 +1,221 @@
+package upload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/zyy125/my-blog/backend/internal/pkg/storage"
+)
+
+// ErrQuotaExceeded 存储空间超过配额
+var ErrQuotaExceeded = errors.New("存储空间不足")
+
+// staleReservationAge 对象键登记后超过该时长仍没有对应文件，视为写入过程中进程退出遗留的登记
+const staleReservationAge = time.Hour
+
+// UsageStore 存储用量记录，按对象键登记每个文件占用的空间
+// 多实例部署时应使用共享的实现（如数据库），否则各实例分别统计，配额会按实例数放大
+type UsageStore interface {
+	// Loaded 是否已登记过存储中的文件（未登记时首次使用前会遍历存储并调用 Load）
+	Loaded(ctx context.Context) (bool, error)
+	// Load 登记存储中已有的文件（objects 为对象键 -> 大小），已登记过时忽略
+	Load(ctx context.Context, objects map[string]int64) error
+	// Reserve 登记对象键并占用 size 字节：键已登记时返回 false；
+	// quota 大于 0 且剩余空间不足时返回 ErrQuotaExceeded。检查和占用必须是原子操作
+	Reserve(ctx context.Context, key string, size, quota int64) (bool, error)
+	// Release 注销对象键并释放其占用的空间（未登记时不报错）
+	Release(ctx context.Context, key string) error
+	// ReleaseStale 对象键在 before 之前登记时注销并释放空间，返回是否已注销
+	ReleaseStale(ctx context.Context, key string, before time.Time) (bool, error)
+	// Total 返回已用空间（字节）和文件数
+	Total(ctx context.Context) (int64, int64, error)
+}
+
+// memoryUsage 只在本实例内存中统计的存储用量（未配置 UsageStore 时使用，重启后重新统计）
+type memoryUsage struct {
+	mu      sync.Mutex
+	loaded  bool
+	objects map[string]memoryObject
+	bytes   int64
+}
+
+// memoryObject 内存中登记的对象
+type memoryObject struct {
+	size       int64
+	reservedAt time.Time // 登记时间（启动时从存储登记的文件为零值）
+}
+
+// Loaded 实现 UsageStore 接口
+func (m *memoryUsage) Loaded(ctx context.Context) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.loaded, nil
+}
+
+// Load 实现 UsageStore 接口
+func (m *memoryUsage) Load(ctx context.Context, objects map[string]int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.loaded {
+		return nil
+	}
+	m.objects, m.bytes, m.loaded = make(map[string]memoryObject, len(objects)), 0, true
+	for key, size := range objects {
+		m.objects[key] = memoryObject{size: size}
+		m.bytes += size
+	}
+	return nil
+}
+
+// Reserve 实现 UsageStore 接口
+func (m *memoryUsage) Reserve(ctx context.Context, key string, size, quota int64) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.objects[key]; ok {
+		return false, nil
+	}
+	if quota > 0 && m.bytes+size > quota {
+		return false, ErrQuotaExceeded
+	}
+	m.objects[key] = memoryObject{size: size, reservedAt: time.Now()}
+	m.bytes += size
+	return true, nil
+}
+
+// Release 实现 UsageStore 接口
+func (m *memoryUsage) Release(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if object, ok := m.objects[key]; ok {
+		delete(m.objects, key)
+		m.bytes -= object.size
+	}
+	return nil
+}
+
+// ReleaseStale 实现 UsageStore 接口
+func (m *memoryUsage) ReleaseStale(ctx context.Context, key string, before time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	object, ok := m.objects[key]
+	if !ok || !object.reservedAt.Before(before) {
+		return false, nil
+	}
+	delete(m.objects, key)
+	m.bytes -= object.size
+	return true, nil
+}
+
+// Total 实现 UsageStore 接口
+func (m *memoryUsage) Total(ctx context.Context) (int64, int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.bytes, int64(len(m.objects)), nil
+}
+
+// usageStore 返回配置的用量记录，未配置时使用本实例的内存统计
+func (c *UploadConfig) usageStore() UsageStore {
+	if c.UsageStore != nil {
+		return c.UsageStore
+	}
+	return &c.usage
+}
+
+// Usage 返回已用空间（字节）和文件数
+func (c *UploadConfig) Usage(ctx context.Context) (int64, int64, error) {
+	if err := c.loadUsage(ctx); err != nil {
+		return 0, 0, err
+	}
+	return c.usageStore().Total(ctx)
+}
+
+// CheckQuota 检查剩余空间能否容纳 size 字节（用于在读取文件前提前拒绝）
+func (c *UploadConfig) CheckQuota(ctx context.Context, size int64) error {
+	if c.Quota <= 0 {
+		return nil
+	}
+	used, _, err := c.Usage(ctx)
+	if err != nil {
+		return err
+	}
+	if used+size > c.Quota {
+		return c.quotaError(used, size)
+	}
+	return nil
+}
+
+// reserve 写入文件前登记对象键并占用空间，返回 false 表示该键已登记（文件已存在或正在写入）
+func (c *UploadConfig) reserve(ctx context.Context, key string, size int64) (bool, error) {
+	if err := c.loadUsage(ctx); err != nil {
+		return false, err
+	}
+	reserved, err := c.usageStore().Reserve(ctx, key, size, c.Quota)
+	if errors.Is(err, ErrQuotaExceeded) {
+		used, _, totalErr := c.usageStore().Total(ctx)
+		if totalErr != nil {
+			return false, err
+		}
+		return false, c.quotaError(used, size)
+	}
+	return reserved, err
+}
+
+// release 删除文件（或写入失败）后注销对象键并释放空间
+func (c *UploadConfig) release(ctx context.Context, key string) error {
+	return c.usageStore().Release(ctx, key)
+}
+
+// reclaimStale 回收登记已久却没有对应文件的对象键（写入存储前进程退出遗留），返回是否已回收
+// 调用方需先确认存储中不存在该文件
+func (c *UploadConfig) reclaimStale(ctx context.Context, key string) (bool, error) {
+	return c.usageStore().ReleaseStale(ctx, key, time.Now().Add(-staleReservationAge))
+}
+
+// loadUsage 用量记录尚未登记时遍历存储登记已有文件
+func (c *UploadConfig) loadUsage(ctx context.Context) error {
+	store := c.usageStore()
+	loaded, err := store.Loaded(ctx)
+	if err != nil {
+		return fmt.Errorf("统计存储用量失败: %v", err)
+	}
+	if loaded {
+		return nil
+	}
+	objects := make(map[string]int64)
+	err = c.Storage.List(ctx, func(obj storage.ObjectInfo) error {
+		objects[obj.Key] = obj.Size
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("统计存储用量失败: %v", err)
+	}
+	if err := store.Load(ctx, objects); err != nil {
+		return fmt.Errorf("统计存储用量失败: %v", err)
+	}
+	return nil
+}
+
+// quotaError 返回带用量信息的配额错误
+func (c *UploadConfig) quotaError(used, size int64) error {
+	return fmt.Errorf("%w：已用 %s / 配额 %s，本次需要 %s", ErrQuotaExceeded,
+		FormatSize(used), FormatSize(c.Quota), FormatSize(size))
+}
+
+// FormatSize 将字节数格式化为易读的大小（如 1.5MB）
+func FormatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%dB", size)
+	}
+	value := float64(size)
+	for _, suffix := range []string{"KB", "MB", "GB", "TB"} {
+		value /= unit
+		if value < unit || suffix == "TB" {
+			return fmt.Sprintf("%.1f%s", value, suffix)
+		}
+	}
+	return ""
+}
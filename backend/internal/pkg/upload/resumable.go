@@ -0,0 +1,449 @@
+package upload
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TusVersion 支持的 tus 断点续传协议版本
+const TusVersion = "1.0.0"
+
+// TusChecksumAlgorithms 支持的校验和算法（tus checksum 扩展）
+const TusChecksumAlgorithms = "sha1,sha256,md5"
+
+// maxOpenUploads 同时进行中的上传任务上限（未配置配额时限制临时数据占用的磁盘空间）
+const maxOpenUploads = 20
+
+// 分片上传的错误（handler 据此返回 tus 协议规定的状态码）
+var (
+	ErrUploadNotFound   = errors.New("上传任务不存在或已过期")
+	ErrUploadLocked     = errors.New("上传任务正在接收数据，请稍后重试")
+	ErrOffsetMismatch   = errors.New("上传偏移量与已接收的数据不一致")
+	ErrExceedsLength    = errors.New("上传数据超过声明的文件大小")
+	ErrChecksumMismatch = errors.New("校验和不匹配")
+	ErrUploadIncomplete = errors.New("文件尚未上传完成")
+	ErrTooManyUploads   = errors.New("进行中的上传任务过多，请先完成或终止已有任务")
+)
+
+// uploadIDPattern 上传任务 ID 格式（防止通过 ID 访问其他路径）
+var uploadIDPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// PartialUpload 分片上传任务
+// 元数据保存为 <id>.json，已接收的数据保存为 <id>.part，已接收大小即为当前偏移量
+type PartialUpload struct {
+	ID        string    `json:"id"`
+	FileName  string    `json:"file_name"`
+	Length    int64     `json:"length"`             // 文件总大小
+	Offset    int64     `json:"offset"`             // 已接收的字节数
+	Checksum  string    `json:"checksum,omitempty"` // 整个文件的校验和（如 "sha256 <Base64>"），完成时校验
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// 完成后的结果
+	Completed bool   `json:"completed"`
+	URL       string `json:"url,omitempty"` // 插入内容时使用的地址（附件为下载地址）
+	MediaID   uint   `json:"media_id,omitempty"`
+}
+
+// Checksum 校验和（格式为 "<算法> <Base64 摘要>"，与 tus 的 Upload-Checksum 头一致）
+type Checksum struct {
+	Algorithm string
+	Sum       []byte
+}
+
+// ParseChecksum 解析校验和
+func ParseChecksum(value string) (*Checksum, error) {
+	algorithm, encoded, ok := strings.Cut(strings.TrimSpace(value), " ")
+	if !ok {
+		return nil, errors.New("校验和格式错误")
+	}
+	algorithm = strings.ToLower(algorithm)
+	if newHash(algorithm) == nil {
+		return nil, fmt.Errorf("不支持的校验和算法：%s", algorithm)
+	}
+	sum, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, errors.New("校验和格式错误")
+	}
+	return &Checksum{Algorithm: algorithm, Sum: sum}, nil
+}
+
+// newHash 根据算法名创建哈希（不支持时返回 nil）
+func newHash(algorithm string) hash.Hash {
+	switch algorithm {
+	case "sha1":
+		return sha1.New()
+	case "sha256":
+		return sha256.New()
+	case "md5":
+		return md5.New()
+	}
+	return nil
+}
+
+// ResumableStore 分片上传的临时数据存储（保存在本地磁盘，完成后通过 Save 写入上传存储）
+type ResumableStore struct {
+	dir    string
+	expire time.Duration
+
+	createMu sync.Mutex // 串行化创建任务，使进行中任务的统计和新任务的创建不被并发请求穿插
+
+	mu     sync.Mutex
+	locked map[string]bool // 正在写入或合并的任务
+}
+
+// NewResumableStore 创建分片上传存储，expire 为未完成任务的保留时间
+func NewResumableStore(dir string, expire time.Duration) *ResumableStore {
+	return &ResumableStore{
+		dir:    dir,
+		expire: expire,
+		locked: make(map[string]bool),
+	}
+}
+
+// Create 创建上传任务，提前按上传策略和存储配额校验文件大小和扩展名
+// 进行中任务声明的大小一并计入配额，避免多个任务各自通过检查后合计超出配额；length 为 0 表示空文件
+func (s *ResumableStore) Create(ctx context.Context, name string, length int64, checksum string, config *UploadConfig) (*PartialUpload, error) {
+	// 1. 参数校验
+	name = path.Base(strings.ReplaceAll(strings.TrimSpace(name), "\\", "/"))
+	if name == "" || name == "." || name == "/" {
+		return nil, errors.New("缺少文件名")
+	}
+	if length < 0 {
+		return nil, errors.New("文件大小无效")
+	}
+	if _, _, err := config.Check(name, length); err != nil {
+		return nil, err
+	}
+	if checksum != "" {
+		if _, err := ParseChecksum(checksum); err != nil {
+			return nil, err
+		}
+	}
+
+	// 2. 顺便清理过期任务，再按进行中的任务检查数量和配额
+	s.createMu.Lock()
+	defer s.createMu.Unlock()
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return nil, fmt.Errorf("创建临时目录失败: %v", err)
+	}
+	s.sweep()
+	open, pending := s.pending()
+	if open >= maxOpenUploads {
+		return nil, ErrTooManyUploads
+	}
+	if config.Quota > 0 {
+		used, _, err := config.Usage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if used+pending+length > config.Quota {
+			return nil, config.quotaError(used+pending, length)
+		}
+	}
+
+	// 3. 创建空的数据文件和元数据
+	id, err := newUploadID()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	up := &PartialUpload{
+		ID:        id,
+		FileName:  name,
+		Length:    length,
+		Checksum:  checksum,
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.expire),
+	}
+	if err := os.WriteFile(s.partPath(id), nil, 0644); err != nil {
+		return nil, fmt.Errorf("创建临时文件失败: %v", err)
+	}
+	if err := s.save(up); err != nil {
+		os.Remove(s.partPath(id))
+		return nil, err
+	}
+	return up, nil
+}
+
+// Get 查询上传任务及已接收的字节数
+func (s *ResumableStore) Get(id string) (*PartialUpload, error) {
+	if !uploadIDPattern.MatchString(id) {
+		return nil, ErrUploadNotFound
+	}
+	data, err := os.ReadFile(s.infoPath(id))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrUploadNotFound
+		}
+		return nil, err
+	}
+	var up PartialUpload
+	if err := json.Unmarshal(data, &up); err != nil {
+		return nil, fmt.Errorf("读取上传任务失败: %v", err)
+	}
+	if time.Now().After(up.ExpiresAt) {
+		return nil, ErrUploadNotFound
+	}
+
+	if up.Completed {
+		up.Offset = up.Length
+		return &up, nil
+	}
+	stat, err := os.Stat(s.partPath(id))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrUploadNotFound
+		}
+		return nil, err
+	}
+	up.Offset = stat.Size()
+	return &up, nil
+}
+
+// Write 从 offset 处追加一段数据，返回更新后的任务
+// checksum 不为空时校验本段数据，不一致则丢弃本段；未校验时连接中断前收到的数据会保留，便于续传
+func (s *ResumableStore) Write(id string, offset int64, src io.Reader, checksum *Checksum) (*PartialUpload, error) {
+	// 1. 同一任务同时只允许一个请求写入
+	if !s.lock(id) {
+		return nil, ErrUploadLocked
+	}
+	defer s.unlock(id)
+
+	// 2. 偏移量必须与已接收的数据一致
+	up, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if up.Completed || offset != up.Offset {
+		return nil, ErrOffsetMismatch
+	}
+
+	// 3. 追加数据（最多读取剩余大小 + 1 字节，用于判断是否超出）
+	f, err := os.OpenFile(s.partPath(id), os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("打开临时文件失败: %v", err)
+	}
+	defer f.Close()
+
+	var w io.Writer = f
+	var h hash.Hash
+	if checksum != nil {
+		h = newHash(checksum.Algorithm)
+		w = io.MultiWriter(f, h)
+	}
+	n, copyErr := io.Copy(w, io.LimitReader(src, up.Length-offset+1))
+
+	// 4. 超出声明大小或校验失败时丢弃本段数据
+	discard := func(err error) (*PartialUpload, error) {
+		if truncErr := f.Truncate(offset); truncErr != nil {
+			return nil, fmt.Errorf("回滚临时文件失败: %v", truncErr)
+		}
+		return nil, err
+	}
+	if offset+n > up.Length {
+		return discard(ErrExceedsLength)
+	}
+	if checksum != nil && (copyErr != nil || !bytes.Equal(h.Sum(nil), checksum.Sum)) {
+		if copyErr != nil {
+			return discard(fmt.Errorf("接收数据失败: %v", copyErr))
+		}
+		return discard(ErrChecksumMismatch)
+	}
+
+	up.Offset = offset + n
+	if copyErr != nil {
+		return up, fmt.Errorf("接收数据失败: %v", copyErr)
+	}
+	return up, nil
+}
+
+// Complete 校验整个文件的校验和，并通过 Save 写入上传存储
+// 成功后删除临时数据，任务标记为已完成（元数据保留到过期，便于客户端查询结果）
+func (s *ResumableStore) Complete(ctx context.Context, id string, config *UploadConfig) (*FileInfo, error) {
+	if !s.lock(id) {
+		return nil, ErrUploadLocked
+	}
+	defer s.unlock(id)
+
+	// 1. 检查是否已接收全部数据
+	up, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if up.Completed || up.Offset != up.Length {
+		return nil, ErrUploadIncomplete
+	}
+
+	f, err := os.Open(s.partPath(id))
+	if err != nil {
+		return nil, fmt.Errorf("打开临时文件失败: %v", err)
+	}
+	defer f.Close()
+
+	// 2. 校验整个文件
+	if up.Checksum != "" {
+		checksum, err := ParseChecksum(up.Checksum)
+		if err != nil {
+			return nil, err
+		}
+		h := newHash(checksum.Algorithm)
+		if _, err := io.Copy(h, f); err != nil {
+			return nil, fmt.Errorf("读取临时文件失败: %v", err)
+		}
+		if !bytes.Equal(h.Sum(nil), checksum.Sum) {
+			return nil, ErrChecksumMismatch
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return nil, fmt.Errorf("读取临时文件失败: %v", err)
+		}
+	}
+
+	// 3. 与普通上传相同的校验和处理流程
+	info, err := Save(ctx, up.FileName, f, config)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. 标记完成并删除临时数据
+	up.Completed = true
+	up.URL = info.Link()
+	if err := s.save(up); err != nil {
+		return nil, err
+	}
+	f.Close()
+	os.Remove(s.partPath(id))
+	return info, nil
+}
+
+// SetMediaID 记录完成后的媒体 ID，之后查询任务时一并返回
+func (s *ResumableStore) SetMediaID(id string, mediaID uint) error {
+	up, err := s.Get(id)
+	if err != nil {
+		return err
+	}
+	up.MediaID = mediaID
+	return s.save(up)
+}
+
+// Delete 终止上传任务并删除临时数据
+func (s *ResumableStore) Delete(id string) error {
+	if !s.lock(id) {
+		return ErrUploadLocked
+	}
+	defer s.unlock(id)
+
+	if _, err := s.Get(id); err != nil {
+		return err
+	}
+	s.remove(id)
+	return nil
+}
+
+// sweep 删除已过期的任务
+func (s *ResumableStore) sweep() {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok || !uploadIDPattern.MatchString(id) {
+			continue
+		}
+		if _, err := s.Get(id); errors.Is(err, ErrUploadNotFound) && s.lock(id) {
+			s.remove(id)
+			s.unlock(id)
+		}
+	}
+}
+
+// pending 统计进行中（未完成且未过期）的任务数和声明的文件大小合计
+func (s *ResumableStore) pending() (int, int64) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return 0, 0
+	}
+	var count int
+	var total int64
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok || !uploadIDPattern.MatchString(id) {
+			continue
+		}
+		if up, err := s.Get(id); err == nil && !up.Completed {
+			count++
+			total += up.Length
+		}
+	}
+	return count, total
+}
+
+// save 写入任务元数据
+func (s *ResumableStore) save(up *PartialUpload) error {
+	data, err := json.Marshal(up)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(s.infoPath(up.ID), data, 0644); err != nil {
+		return fmt.Errorf("保存上传任务失败: %v", err)
+	}
+	return nil
+}
+
+// remove 删除任务的元数据和临时数据
+func (s *ResumableStore) remove(id string) {
+	os.Remove(s.partPath(id))
+	os.Remove(s.infoPath(id))
+}
+
+func (s *ResumableStore) lock(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.locked[id] {
+		return false
+	}
+	s.locked[id] = true
+	return true
+}
+
+func (s *ResumableStore) unlock(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.locked, id)
+}
+
+func (s *ResumableStore) infoPath(id string) string {
+	return filepath.Join(s.dir, id+".json")
+}
+
+func (s *ResumableStore) partPath(id string) string {
+	return filepath.Join(s.dir, id+".part")
+}
+
+// newUploadID 生成随机的任务 ID
+func newUploadID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("生成上传任务 ID 失败: %v", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,428 @@
+package upload
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	_ "image/gif"  // 注册 GIF 解码器
+	_ "image/jpeg" // 注册 JPEG 解码器
+	_ "image/png"  // 注册 PNG 解码器
+	"io"
+	"path"
+	"strings"
+
+	_ "golang.org/x/image/webp" // 注册 WebP 解码器
+)
+
+// maxImagePixels 允许的最大像素数（防止解压炸弹）
+const maxImagePixels = 50 * 1000 * 1000
+
+// 文件类别（每个类别有独立的上传策略）
+const (
+	CategoryImage    = "image"
+	CategoryDocument = "document"
+	CategoryArchive  = "archive"
+	CategoryAudio    = "audio"
+)
+
+// Categories 所有文件类别
+var Categories = []string{CategoryImage, CategoryDocument, CategoryArchive, CategoryAudio}
+
+// FileType 根据文件内容识别出的类型
+type FileType struct {
+	MIME     string   // MIME 类型
+	Ext      string   // 保存时使用的扩展名
+	Exts     []string // 与该类型对应的客户端扩展名
+	Format   string   // image 包中的解码器名称（非图片为空）
+	Category string   // 文件类别
+}
+
+// 支持识别的文件类型
+var (
+	TypeJPEG = &FileType{MIME: "image/jpeg", Ext: ".jpg", Exts: []string{".jpg", ".jpeg"}, Format: "jpeg", Category: CategoryImage}
+	TypePNG  = &FileType{MIME: "image/png", Ext: ".png", Exts: []string{".png"}, Format: "png", Category: CategoryImage}
+	TypeGIF  = &FileType{MIME: "image/gif", Ext: ".gif", Exts: []string{".gif"}, Format: "gif", Category: CategoryImage}
+	TypeWebP = &FileType{MIME: "image/webp", Ext: ".webp", Exts: []string{".webp"}, Format: "webp", Category: CategoryImage}
+
+	TypePDF  = &FileType{MIME: "application/pdf", Ext: ".pdf", Exts: []string{".pdf"}, Category: CategoryDocument}
+	TypeDOCX = &FileType{MIME: "application/vnd.openxmlformats-officedocument.wordprocessingml.document", Ext: ".docx", Exts: []string{".docx"}, Category: CategoryDocument}
+	TypePPTX = &FileType{MIME: "application/vnd.openxmlformats-officedocument.presentationml.presentation", Ext: ".pptx", Exts: []string{".pptx"}, Category: CategoryDocument}
+	TypeXLSX = &FileType{MIME: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", Ext: ".xlsx", Exts: []string{".xlsx"}, Category: CategoryDocument}
+
+	TypeZIP  = &FileType{MIME: "application/zip", Ext: ".zip", Exts: []string{".zip"}, Category: CategoryArchive}
+	TypeGzip = &FileType{MIME: "application/gzip", Ext: ".gz", Exts: []string{".gz", ".tgz"}, Category: CategoryArchive}
+	Type7z   = &FileType{MIME: "application/x-7z-compressed", Ext: ".7z", Exts: []string{".7z"}, Category: CategoryArchive}
+	TypeRAR  = &FileType{MIME: "application/vnd.rar", Ext: ".rar", Exts: []string{".rar"}, Category: CategoryArchive}
+
+	TypeMP3  = &FileType{MIME: "audio/mpeg", Ext: ".mp3", Exts: []string{".mp3"}, Category: CategoryAudio}
+	TypeM4A  = &FileType{MIME: "audio/mp4", Ext: ".m4a", Exts: []string{".m4a"}, Category: CategoryAudio}
+	TypeOGG  = &FileType{MIME: "audio/ogg", Ext: ".ogg", Exts: []string{".ogg", ".oga"}, Category: CategoryAudio}
+	TypeWAV  = &FileType{MIME: "audio/wav", Ext: ".wav", Exts: []string{".wav"}, Category: CategoryAudio}
+	TypeFLAC = &FileType{MIME: "audio/flac", Ext: ".flac", Exts: []string{".flac"}, Category: CategoryAudio}
+)
+
+// knownTypes 所有已知类型（用于按扩展名查找类型和 Content-Type）
+var knownTypes = []*FileType{
+	TypeJPEG, TypePNG, TypeGIF, TypeWebP,
+	TypePDF, TypeDOCX, TypePPTX, TypeXLSX,
+	TypeZIP, TypeGzip, Type7z, TypeRAR,
+	TypeMP3, TypeM4A, TypeOGG, TypeWAV, TypeFLAC,
+}
+
+// officeTypes Office Open XML 文档中标志文档类型的文件
+var officeTypes = map[string]*FileType{
+	"word/document.xml":    TypeDOCX,
+	"ppt/presentation.xml": TypePPTX,
+	"xl/workbook.xml":      TypeXLSX,
+}
+
+// activeMarkers 出现在图片元数据中即视为多格式伪装文件（polyglot）的内容（小写）
+var activeMarkers = [][]byte{
+	[]byte("<script"), []byte("<html"), []byte("<!doctype"), []byte("<iframe"),
+	[]byte("<svg"), []byte("<body"), []byte("<?php"), []byte("<%@"),
+}
+
+// IsImage 是否为图片类型
+func (t *FileType) IsImage() bool {
+	return t.Format != ""
+}
+
+// MatchExt 判断客户端扩展名是否与识别出的类型一致
+func (t *FileType) MatchExt(ext string) bool {
+	for _, e := range t.Exts {
+		if e == ext {
+			return true
+		}
+	}
+	return false
+}
+
+// sniffLen 识别文件类型时读取的文件头长度
+const sniffLen = 512
+
+// Detect 根据文件头魔数识别文件类型，无法识别时返回 nil
+func Detect(data []byte) *FileType {
+	return DetectReader(bytes.NewReader(data), int64(len(data)))
+}
+
+// DetectReader 读取文件头识别文件类型（ZIP 需读取末尾的中央目录区分 Office 文档），无法识别时返回 nil
+func DetectReader(r io.ReaderAt, size int64) *FileType {
+	header := make([]byte, min(size, sniffLen))
+	if _, err := r.ReadAt(header, 0); err != nil && err != io.EOF {
+		return nil
+	}
+	t := detectHeader(header)
+	if t == TypeZIP {
+		return detectZip(r, size)
+	}
+	return t
+}
+
+// detectHeader 根据文件头魔数识别文件类型
+func detectHeader(data []byte) *FileType {
+	switch {
+	case bytes.HasPrefix(data, []byte("\xFF\xD8\xFF")):
+		return TypeJPEG
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return TypePNG
+	case bytes.HasPrefix(data, []byte("GIF87a")), bytes.HasPrefix(data, []byte("GIF89a")):
+		return TypeGIF
+	case len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		return TypeWebP
+	case bytes.HasPrefix(data, []byte("%PDF-")):
+		return TypePDF
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")), bytes.HasPrefix(data, []byte("PK\x05\x06")):
+		return TypeZIP
+	case bytes.HasPrefix(data, []byte("\x1F\x8B")):
+		return TypeGzip
+	case bytes.HasPrefix(data, []byte("7z\xBC\xAF\x27\x1C")):
+		return Type7z
+	case bytes.HasPrefix(data, []byte("Rar!\x1A\x07")):
+		return TypeRAR
+	case bytes.HasPrefix(data, []byte("ID3")), len(data) >= 2 && data[0] == 0xFF && data[1]&0xE0 == 0xE0:
+		return TypeMP3
+	case len(data) >= 12 && string(data[4:8]) == "ftyp" && (string(data[8:12]) == "M4A " || string(data[8:12]) == "M4B "):
+		return TypeM4A
+	case bytes.HasPrefix(data, []byte("OggS")):
+		return TypeOGG
+	case len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WAVE":
+		return TypeWAV
+	case bytes.HasPrefix(data, []byte("fLaC")):
+		return TypeFLAC
+	}
+	return nil
+}
+
+// detectZip 区分普通 ZIP 和 Office Open XML 文档（docx/pptx/xlsx 均为 ZIP 格式）
+func detectZip(r io.ReaderAt, size int64) *FileType {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return TypeZIP
+	}
+	for _, f := range zr.File {
+		if t, ok := officeTypes[f.Name]; ok {
+			return t
+		}
+	}
+	return TypeZIP
+}
+
+// TypeByExt 根据扩展名查找文件类型，未知扩展名返回 nil
+func TypeByExt(ext string) *FileType {
+	ext = strings.ToLower(ext)
+	for _, t := range knownTypes {
+		if t.MatchExt(ext) {
+			return t
+		}
+	}
+	return nil
+}
+
+// TypeByMIME 根据 MIME 类型查找文件类型，未知类型返回 nil
+func TypeByMIME(mime string) *FileType {
+	for _, t := range knownTypes {
+		if t.MIME == mime {
+			return t
+		}
+	}
+	return nil
+}
+
+// MIMETypes 返回某个类别下所有支持的 MIME 类型
+func MIMETypes(category string) []string {
+	var types []string
+	for _, t := range knownTypes {
+		if t.Category == category {
+			types = append(types, t.MIME)
+		}
+	}
+	return types
+}
+
+// ContentType 根据文件名扩展名返回 Content-Type，未知类型返回 application/octet-stream
+func ContentType(name string) string {
+	if t := TypeByExt(path.Ext(name)); t != nil {
+		return t.MIME
+	}
+	return "application/octet-stream"
+}
+
+// ValidateImage 校验图片内容：解码文件头、检查尺寸、校验文件结构完整且末尾没有附加数据，
+// 并拒绝在元数据中嵌入 HTML/脚本的伪装文件。返回图片宽高
+// 像素数据是压缩后的任意字节，不做扫描（随机数据中难免出现标记），由重新编码和 nosniff 响应头兜底
+func ValidateImage(data []byte, t *FileType) (int, int, error) {
+	// 1. 解码文件头，确认格式与魔数一致
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || format != t.Format {
+		return 0, 0, errors.New("图片文件已损坏或格式不正确")
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return 0, 0, errors.New("图片尺寸无效")
+	}
+	if cfg.Width*cfg.Height > maxImagePixels {
+		return 0, 0, errors.New("图片像素过大")
+	}
+
+	// 2. 按格式解析文件结构，找到图片数据的结束位置并收集元数据
+	var end int
+	var meta [][]byte
+	switch t {
+	case TypeJPEG:
+		end, meta, err = jpegEnd(data)
+	case TypePNG:
+		end, meta, err = pngEnd(data)
+	case TypeGIF:
+		end, meta, err = gifEnd(data)
+	case TypeWebP:
+		end, meta, err = webpEnd(data)
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	if end != len(data) {
+		return 0, 0, errors.New("图片末尾包含额外数据")
+	}
+
+	// 3. 拒绝在元数据中嵌入 HTML/脚本的图片
+	for _, m := range meta {
+		lower := bytes.ToLower(m)
+		for _, marker := range activeMarkers {
+			if bytes.Contains(lower, marker) {
+				return 0, 0, errors.New("图片中包含可执行内容")
+			}
+		}
+	}
+
+	return cfg.Width, cfg.Height, nil
+}
+
+// errBadStructure 文件结构损坏
+var errBadStructure = errors.New("图片文件结构损坏")
+
+// pngEnd 遍历 PNG 数据块（校验 CRC），返回 IEND 块之后的位置和文本块内容
+func pngEnd(data []byte) (int, [][]byte, error) {
+	var meta [][]byte
+	off := 8
+	for off+12 <= len(data) {
+		length := int(binary.BigEndian.Uint32(data[off:]))
+		if length < 0 || off+12+length > len(data) {
+			return 0, nil, errBadStructure
+		}
+		chunk := data[off+4 : off+8+length]
+		crc := binary.BigEndian.Uint32(data[off+8+length:])
+		if crc32.ChecksumIEEE(chunk) != crc {
+			return 0, nil, errBadStructure
+		}
+		off += 12 + length
+		switch string(chunk[:4]) {
+		case "tEXt", "zTXt", "iTXt":
+			meta = append(meta, chunk[4:])
+		case "IEND":
+			return off, meta, nil
+		}
+	}
+	return 0, nil, errBadStructure
+}
+
+// jpegEnd 遍历 JPEG 段和熵编码数据，返回 EOI 标记之后的位置和 APPn/COM 段内容
+func jpegEnd(data []byte) (int, [][]byte, error) {
+	var meta [][]byte
+	off := 2
+	for off+1 < len(data) {
+		if data[off] != 0xFF {
+			return 0, nil, errBadStructure
+		}
+		marker := data[off+1]
+		off += 2
+
+		switch {
+		case marker == 0xFF:
+			off-- // 填充字节
+		case marker == 0xD9: // EOI
+			return off, meta, nil
+		case marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7): // 无长度的标记
+		default:
+			if off+2 > len(data) {
+				return 0, nil, errBadStructure
+			}
+			length := int(binary.BigEndian.Uint16(data[off:]))
+			if length < 2 || off+length > len(data) {
+				return 0, nil, errBadStructure
+			}
+			if (marker >= 0xE0 && marker <= 0xEF) || marker == 0xFE { // APPn、COM
+				meta = append(meta, data[off+2:off+length])
+			}
+			off += length
+
+			// SOS 之后是熵编码数据，其中 0xFF 只会跟随 0x00 或 RST 标记
+			if marker == 0xDA {
+				for off+1 < len(data) {
+					if data[off] != 0xFF {
+						off++
+						continue
+					}
+					next := data[off+1]
+					if next == 0x00 || (next >= 0xD0 && next <= 0xD7) {
+						off += 2
+						continue
+					}
+					break
+				}
+			}
+		}
+	}
+	return 0, nil, errBadStructure
+}
+
+// gifEnd 遍历 GIF 数据块，返回结束符之后的位置和注释/应用扩展块内容
+func gifEnd(data []byte) (int, [][]byte, error) {
+	if len(data) < 13 {
+		return 0, nil, errBadStructure
+	}
+	var meta [][]byte
+	off := 13
+	if flags := data[10]; flags&0x80 != 0 {
+		off += 3 << (uint(flags&0x07) + 1) // 全局颜色表
+	}
+
+	// skipSubBlocks 跳过数据子块序列，collect 非空时把子块数据拼接到其中
+	skipSubBlocks := func(collect *[]byte) bool {
+		for off < len(data) {
+			size := int(data[off])
+			off++
+			if size == 0 {
+				return true
+			}
+			if collect != nil && off+size <= len(data) {
+				*collect = append(*collect, data[off:off+size]...)
+			}
+			off += size
+		}
+		return false
+	}
+
+	for off < len(data) {
+		switch data[off] {
+		case 0x3B: // 结束符
+			return off + 1, meta, nil
+		case 0x21: // 扩展块
+			if off+1 >= len(data) {
+				return 0, nil, errBadStructure
+			}
+			label := data[off+1]
+			off += 2
+			if label == 0xFE || label == 0xFF { // 注释扩展、应用扩展
+				var block []byte
+				if !skipSubBlocks(&block) {
+					return 0, nil, errBadStructure
+				}
+				meta = append(meta, block)
+			} else if !skipSubBlocks(nil) {
+				return 0, nil, errBadStructure
+			}
+		case 0x2C: // 图像描述符
+			if off+10 > len(data) {
+				return 0, nil, errBadStructure
+			}
+			flags := data[off+9]
+			off += 10
+			if flags&0x80 != 0 {
+				off += 3 << (uint(flags&0x07) + 1) // 局部颜色表
+			}
+			off++ // LZW 最小码长
+			if !skipSubBlocks(nil) {
+				return 0, nil, errBadStructure
+			}
+		default:
+			return 0, nil, errBadStructure
+		}
+	}
+	return 0, nil, errBadStructure
+}
+
+// webpEnd 根据 RIFF 头中的长度返回文件结束位置，并遍历数据块收集 EXIF/XMP 内容
+func webpEnd(data []byte) (int, [][]byte, error) {
+	size := int(binary.LittleEndian.Uint32(data[4:8]))
+	end := 8 + size
+	if size < 4 || end > len(data) {
+		return 0, nil, errBadStructure
+	}
+
+	var meta [][]byte
+	off := 12
+	for off+8 <= end {
+		length := int(binary.LittleEndian.Uint32(data[off+4:]))
+		if length < 0 || off+8+length > end {
+			return 0, nil, errBadStructure
+		}
+		switch string(data[off : off+4]) {
+		case "EXIF", "XMP ":
+			meta = append(meta, data[off+8:off+8+length])
+		}
+		off += 8 + length + length&1 // 数据块按偶数字节对齐
+	}
+	return end, meta, nil
+}
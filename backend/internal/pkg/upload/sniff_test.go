@@ -0,0 +1,81 @@
+package upload
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"math/rand"
+	"testing"
+)
+
+// noiseJPEG 生成随机噪点 JPEG（高质量噪点图压缩后接近原始大小，熵编码数据基本是随机字节）
+func noiseJPEG(t *testing.T, seed int64, width, height int) []byte {
+	t.Helper()
+	rnd := rand.New(rand.NewSource(seed))
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{uint8(rnd.Intn(256)), uint8(rnd.Intn(256)), uint8(rnd.Intn(256)), 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 100}); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// withJPEGComment 在 SOI 之后插入 COM 段
+func withJPEGComment(data []byte, comment string) []byte {
+	length := len(comment) + 2
+	seg := append([]byte{0xFF, 0xFE, byte(length >> 8), byte(length)}, comment...)
+	out := append([]byte{}, data[:2]...)
+	out = append(out, seg...)
+	return append(out, data[2:]...)
+}
+
+func TestValidateImageLargeJPEG(t *testing.T) {
+	for seed := int64(1); seed <= 3; seed++ {
+		data := noiseJPEG(t, seed, 2000, 1500)
+		if len(data) < 4<<20 {
+			t.Fatalf("seed %d: 测试图片过小 %d 字节", seed, len(data))
+		}
+		w, h, err := ValidateImage(data, TypeJPEG)
+		if err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+		if w != 2000 || h != 1500 {
+			t.Fatalf("seed %d: 尺寸 %dx%d", seed, w, h)
+		}
+	}
+}
+
+func TestValidateImageJPEGMetadata(t *testing.T) {
+	data := noiseJPEG(t, 1, 64, 64)
+
+	tests := []struct {
+		name    string
+		comment string
+		wantErr bool
+	}{
+		{"普通注释", "created by camera", false},
+		{"脚本注释", "<SCRIPT>alert(1)</script>", true},
+		{"HTML 注释", "<!DOCTYPE html><html>", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ValidateImage(withJPEGComment(data, tt.comment), TypeJPEG)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateImageTrailingData(t *testing.T) {
+	data := append(noiseJPEG(t, 1, 64, 64), "<?php echo 1; ?>"...)
+	if _, _, err := ValidateImage(data, TypeJPEG); err == nil {
+		t.Fatal("末尾附加数据应被拒绝")
+	}
+}
@@ -1,83 +1,276 @@
 package upload
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"mime/multipart"
 	"os"
 	"path"
 	"strings"
-	"time"
+
+	"github.com/zyy125/my-blog/backend/internal/pkg/imaging"
+	"github.com/zyy125/my-blog/backend/internal/pkg/storage"
 )
 
 // UploadConfig 上传配置
 type UploadConfig struct {
-	SavePath    string   // 保存路径
-	MaxSize     int64    // 最大文件大小（字节）
-	AllowedExts []string // 允许的扩展名
+	Storage  storage.Storage    // 存储后端
+	Policies map[string]*Policy // 各类文件的上传策略（键为文件类别），未配置的类别不允许上传
+	Image    imaging.Options    // 图片处理选项（零值时只去除元数据）
+	Quota    int64              // 存储配额（字节），为 0 时不限制
+
+	// UsageStore 存储用量记录（多实例部署时需配置共享的实现），为空时只在本实例内存中统计
+	UsageStore UsageStore
+
+	usage memoryUsage // 未配置 UsageStore 时使用
+}
+
+// Policy 某类文件的上传策略
+type Policy struct {
+	MaxSize      int64    // 最大文件大小（字节）
+	AllowedTypes []string // 允许的 MIME 类型
 }
 
-// DefaultConfig 默认配置
+// DefaultConfig 默认配置（只允许上传图片）
 var DefaultConfig = &UploadConfig{
-	SavePath:    "./uploads",
-	MaxSize:     10 * 1024 * 1024, // 10MB
-	AllowedExts: []string{".jpg", ".jpeg", ".png", ".gif", ".webp"},
+	Storage: storage.NewLocal("./uploads", "/uploads/"),
+	Policies: map[string]*Policy{
+		CategoryImage: {
+			MaxSize:      10 * 1024 * 1024, // 10MB
+			AllowedTypes: []string{"image/jpeg", "image/png", "image/gif", "image/webp"},
+		},
+	},
+}
+
+// MaxSize 返回所有策略中最大的文件大小
+func (c *UploadConfig) MaxSize() int64 {
+	var max int64
+	for _, policy := range c.Policies {
+		if policy.MaxSize > max {
+			max = policy.MaxSize
+		}
+	}
+	return max
+}
+
+// Check 根据文件名和大小检查是否允许上传，categories 为空时允许所有已配置的类别
+// 返回扩展名对应的文件类型及其策略
+func (c *UploadConfig) Check(name string, size int64, categories ...string) (*FileType, *Policy, error) {
+	ext := strings.ToLower(path.Ext(name))
+	fileType := TypeByExt(ext)
+	if fileType == nil || (len(categories) > 0 && !contains(categories, fileType.Category)) {
+		return nil, nil, fmt.Errorf("不支持的文件类型：%s", ext)
+	}
+	policy := c.Policies[fileType.Category]
+	if policy == nil || !contains(policy.AllowedTypes, fileType.MIME) {
+		return nil, nil, fmt.Errorf("不支持的文件类型：%s", ext)
+	}
+	if size > policy.MaxSize {
+		return nil, nil, fmt.Errorf("文件大小超过限制（最大%dMB）", policy.MaxSize/1024/1024)
+	}
+	return fileType, policy, nil
 }
 
 // SaveImage 保存图片
-func SaveImage(file *multipart.FileHeader) (string, error) {
-	return SaveFile(file, DefaultConfig)
+func SaveImage(ctx context.Context, file *multipart.FileHeader) (*FileInfo, error) {
+	return SaveFile(ctx, file, DefaultConfig, CategoryImage)
 }
 
-// SaveFile 保存文件
-func SaveFile(file *multipart.FileHeader, config *UploadConfig) (string, error) {
-	// 1. 验证文件大小
-	if file.Size > config.MaxSize {
-		return "", fmt.Errorf("文件大小超过限制（最大%dMB）", config.MaxSize/1024/1024)
-	}
-	
-	// 2. 验证文件扩展名
-	ext := strings.ToLower(path.Ext(file.Filename))
-	allowed := false
-	for _, allowedExt := range config.AllowedExts {
-		if ext == allowedExt {
-			allowed = true
-			break
-		}
+// SaveFile 保存文件，返回文件信息（访问路径、大小、类型、尺寸、哈希）
+// 文件按内容寻址存储，重复上传相同内容时返回已有文件的地址；categories 为空时允许所有已配置的类别
+func SaveFile(ctx context.Context, file *multipart.FileHeader, config *UploadConfig, categories ...string) (*FileInfo, error) {
+	// 1. 验证文件类型、大小和剩余空间
+	if _, _, err := config.Check(file.Filename, file.Size, categories...); err != nil {
+		return nil, err
+	}
+	if err := config.CheckQuota(ctx, file.Size); err != nil {
+		return nil, err
 	}
-	if !allowed {
-		return "", fmt.Errorf("不支持的文件类型：%s", ext)
-	}
-	
-	// 3. 生成文件名（按日期分目录）
-	now := time.Now()
-	datePath := now.Format("2006/01/02")
-	filename := fmt.Sprintf("%d%s", now.UnixNano(), ext)
-	
-	// 4. 创建目录
-	savePath := path.Join(config. SavePath, datePath)
-	if err := os.MkdirAll(savePath, os.ModePerm); err != nil {
-		return "", fmt.Errorf("创建目录失败: %v", err)
-	}
-	
-	// 5. 保存文件
-	dst := path.Join(savePath, filename)
+
+	// 2. 打开文件
 	src, err := file.Open()
 	if err != nil {
-		return "", fmt.Errorf("打开文件失败: %v", err)
+		return nil, fmt.Errorf("打开文件失败: %v", err)
 	}
 	defer src.Close()
-	
-	out, err := os.Create(dst)
+
+	return Save(ctx, file.Filename, src, config, categories...)
+}
+
+// Save 校验并保存 src 中的文件内容，name 为原始文件名（用于校验扩展名）
+// 内容先边计算哈希边写入临时文件，只有图片（需要解码处理）才会读入内存
+func Save(ctx context.Context, name string, src io.Reader, config *UploadConfig, categories ...string) (*FileInfo, error) {
+	// 1. 按扩展名查找上传策略
+	expected, policy, err := config.Check(name, 0, categories...)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 写入临时文件，同时按上传内容计算 SHA-256
+	tmp, err := os.CreateTemp("", "upload-*")
+	if err != nil {
+		return nil, fmt.Errorf("创建临时文件失败: %v", err)
+	}
+	defer func() {
+		tmp.Close()
+		os.Remove(tmp.Name())
+	}()
+	hasher := sha256.New()
+	size, err := io.Copy(io.MultiWriter(hasher, tmp), io.LimitReader(src, policy.MaxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("读取文件失败: %v", err)
+	}
+	if size > policy.MaxSize {
+		return nil, fmt.Errorf("文件大小超过限制（最大%dMB）", policy.MaxSize/1024/1024)
+	}
+
+	// 3. 根据内容识别类型，必须与扩展名对应的类型一致
+	fileType := DetectReader(tmp, size)
+	if fileType == nil {
+		return nil, errors.New("无法识别的文件内容或不支持的文件类型")
+	}
+	if fileType != expected {
+		return nil, fmt.Errorf("文件扩展名与内容不符（实际为 %s）", fileType.MIME)
+	}
+
+	// 4. 图片需完整校验文件结构
+	var data []byte
+	var width, height int
+	if fileType.IsImage() {
+		data = make([]byte, size)
+		if _, err := tmp.ReadAt(data, 0); err != nil {
+			return nil, fmt.Errorf("读取文件失败: %v", err)
+		}
+		width, height, err = ValidateImage(data, fileType)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 5. 图片去除元数据并生成响应式版本和缩略图（GIF 可能是动图，保持原样）
+	var processed *imaging.Result
+	if fileType.IsImage() && imaging.Supported(fileType.Format) {
+		processed, err = imaging.Process(data, fileType.Format, config.Image)
+		if err != nil {
+			return nil, err
+		}
+		data = processed.Original.Data
+		size = int64(len(data))
+		width, height = processed.Original.Width, processed.Original.Height
+	}
+
+	// 6. 按上传内容的 SHA-256 生成文件名（内容寻址，相同内容得到相同地址）
+	hash := hex.EncodeToString(hasher.Sum(nil))
+	base := path.Join(hash[:2], hash[2:4], hash)
+
+	// 7. 写入存储：先登记对象键并占用配额（同一个键只有一个请求能登记成功），已存在的文件不再重复写入；
+	// 任一文件失败时删除本次写入的文件
+	var written []string
+	save := func(key string, content io.ReadSeeker, size int64) (string, bool, error) {
+		reserved, err := config.reserve(ctx, key, size)
+		if err != nil {
+			return "", false, rollback(ctx, config, written, err)
+		}
+		if !reserved {
+			if _, err := config.Storage.Stat(ctx, key); err == nil {
+				return config.Storage.URL(key), true, nil
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return "", false, rollback(ctx, config, written, fmt.Errorf("保存文件失败: %v", err))
+			}
+
+			// 已登记但文件不存在：登记已久说明写入前进程退出，回收后重新登记；否则是其他请求正在写入
+			reclaimed, err := config.reclaimStale(ctx, key)
+			if err != nil {
+				return "", false, rollback(ctx, config, written, fmt.Errorf("保存文件失败: %v", err))
+			}
+			if reclaimed {
+				if reserved, err = config.reserve(ctx, key, size); err != nil {
+					return "", false, rollback(ctx, config, written, err)
+				}
+			}
+			if !reserved {
+				return "", false, rollback(ctx, config, written, errors.New("相同内容的文件正在上传，请稍后重试"))
+			}
+		}
+		if err := config.Storage.Put(ctx, key, content, ContentType(key)); err != nil {
+			config.release(ctx, key)
+			return "", false, rollback(ctx, config, written, fmt.Errorf("保存文件失败: %v", err))
+		}
+		written = append(written, key)
+		return config.Storage.URL(key), false, nil
+	}
+
+	// 非图片直接从临时文件写入存储
+	var content io.ReadSeeker = tmp
+	if data != nil {
+		content = bytes.NewReader(data)
+	} else if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("读取文件失败: %v", err)
+	}
+	key := base + fileType.Ext
+	url, existing, err := save(key, content, size)
 	if err != nil {
-		return "", fmt.Errorf("创建文件失败: %v", err)
+		return nil, err
 	}
-	defer out.Close()
-	
-	if _, err := io.Copy(out, src); err != nil {
-		return "", fmt.Errorf("保存文件失败: %v", err)
+	info := &FileInfo{
+		URL:      url,
+		Key:      key,
+		Name:     name,
+		Size:     size,
+		MimeType: fileType.MIME,
+		Category: fileType.Category,
+		Width:    width,
+		Height:   height,
+		Hash:     hash,
+		Existing: existing,
 	}
-	
-	// 6. 返回相对路径（用于前端访问）
-	return "/" + strings.ReplaceAll(dst, "\\", "/"), nil
-}
\ No newline at end of file
+	if !fileType.IsImage() {
+		info.DownloadURL = DownloadURL(key)
+	}
+
+	if processed != nil {
+		for _, v := range processed.Variants {
+			name := fmt.Sprintf("%dw", v.Width)
+			variantURL, _, err := save(base+"-"+name+v.Ext, bytes.NewReader(v.Data), int64(len(v.Data)))
+			if err != nil {
+				return nil, err
+			}
+			info.Variants = append(info.Variants, Variant{Name: name, URL: variantURL, Width: v.Width, Height: v.Height})
+		}
+		if t := processed.Thumbnail; t != nil {
+			thumbURL, _, err := save(base+"-thumb"+t.Ext, bytes.NewReader(t.Data), int64(len(t.Data)))
+			if err != nil {
+				return nil, err
+			}
+			info.Variants = append(info.Variants, Variant{Name: ThumbnailVariant, URL: thumbURL, Width: t.Width, Height: t.Height})
+		}
+	}
+
+	return info, nil
+}
+
+// rollback 删除本次已写入的文件并释放配额，返回原错误
+func rollback(ctx context.Context, config *UploadConfig, written []string, err error) error {
+	for _, key := range written {
+		if config.Storage.Delete(ctx, key) == nil {
+			config.release(ctx, key)
+		}
+	}
+	return err
+}
+
+// contains 判断字符串是否在列表中
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
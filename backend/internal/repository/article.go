@@ -2,10 +2,18 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"github.com/zyy125/my-blog/backend/internal/model"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ArticleFilter 文章列表的通用筛选条件（零值表示不筛选）
+type ArticleFilter struct {
+	Language string            // 语言代码
+	Metadata map[string]string // 自定义字段 -> 值
+}
+
 // ArticleRepository 文章数据访问层
 type ArticleRepository struct {
 	db *gorm.DB
@@ -68,17 +76,83 @@ func (r *ArticleRepository) Update(ctx context.Context, article *model.Article)
 	return r.db.WithContext(ctx).Save(article).Error
 }
 
+// ListTexts 查询所有文章的标题和正文（用于标签推荐的语料统计，可排除一篇文章）
+func (r *ArticleRepository) ListTexts(ctx context.Context, excludeID uint) ([]*model.Article, error) {
+	var articles []*model.Article
+	err := r.db.WithContext(ctx).
+		Select("id, title, content").
+		Where("id <> ?", excludeID).
+		Find(&articles).Error
+	return articles, err
+}
+
 // Delete 删除文章
 func (r *ArticleRepository) Delete(ctx context. Context, id uint) error {
-	return r.db.WithContext(ctx).Delete(&model.Article{}, id).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 清除文章的死链检查记录
+		if err := tx.Where("article_id = ?", id).Delete(&model.BrokenLink{}).Error; err != nil {
+			return err
+		}
+
+		// 2. 删除文章
+		return tx.Delete(&model.Article{}, id).Error
+	})
+}
+
+// IncrementViewsBatch 批量增加浏览量，同时累加到每日浏览量表和每日来源统计表
+func (r *ArticleRepository) IncrementViewsBatch(ctx context.Context, daily []model.ArticleViewDaily, referrers []model.ArticleReferrerDaily) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 按文章汇总总浏览量
+		totals := make(map[uint]int)
+		for _, row := range daily {
+			totals[row.ArticleID] += row.Views
+		}
+		for id, count := range totals {
+			err := tx.Model(&model.Article{}).
+				Where("id = ?", id).
+				UpdateColumn("views", gorm.Expr("views + ?", count)).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		// 2. 累加每日浏览量（不存在则插入）
+		for i := range daily {
+			err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "article_id"}, {Name: "date"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{"views": gorm.Expr("views + ?", daily[i].Views)}),
+			}).Create(&daily[i]).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		// 3. 累加每日来源统计
+		for i := range referrers {
+			err := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{
+					{Name: "article_id"}, {Name: "date"}, {Name: "referrer"},
+					{Name: "utm_source"}, {Name: "utm_medium"}, {Name: "utm_campaign"},
+				},
+				DoUpdates: clause.Assignments(map[string]interface{}{"views": gorm.Expr("views + ?", referrers[i].Views)}),
+			}).Create(&referrers[i]).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
-// IncrementViews 增加浏览量
-func (r *ArticleRepository) IncrementViews(ctx context.Context, id uint) error {
+// UpdatePassword 更新文章访问密码（hash 为空表示取消加密）
+func (r *ArticleRepository) UpdatePassword(ctx context.Context, id uint, hash string) error {
 	return r.db.WithContext(ctx).
 		Model(&model.Article{}).
 		Where("id = ?", id).
-		UpdateColumn("views", gorm. Expr("views + 1")).Error
+		Updates(map[string]interface{}{
+			"password":     hash,
+			"is_protected": hash != "",
+		}).Error
 }
 
 // GetByIDWithAssociations 根据ID查询文章（包含分类和标签）
@@ -86,6 +160,7 @@ func (r *ArticleRepository) GetByIDWithAssociations(ctx context.Context, id uint
 	var article model.Article
 	err := r.db.WithContext(ctx).
 		Preload("Category").  // 预加载分类
+		Preload("Author").    // 预加载作者
 		Preload("Tags").      // 预加载标签
 		First(&article, id).Error
 	if err != nil {
@@ -95,7 +170,7 @@ func (r *ArticleRepository) GetByIDWithAssociations(ctx context.Context, id uint
 }
 
 // ListWithAssociations 查询文章列表（包含分类和标签）
-func (r *ArticleRepository) ListWithAssociations(ctx context.Context, page, pageSize int, status *int8) ([]*model.Article, int64, error) {
+func (r *ArticleRepository) ListWithAssociations(ctx context.Context, page, pageSize int, status *int8, filter ArticleFilter) ([]*model.Article, int64, error) {
 	var articles []*model.Article
 	var total int64
 	
@@ -104,6 +179,7 @@ func (r *ArticleRepository) ListWithAssociations(ctx context.Context, page, page
 	if status != nil {
 		query = query.Where("status = ?", *status)
 	}
+	query = applyFilter(query, filter)
 	
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -112,6 +188,7 @@ func (r *ArticleRepository) ListWithAssociations(ctx context.Context, page, page
 	offset := (page - 1) * pageSize
 	err := query. 
 		Preload("Category").  // ✅ 预加载分类
+		Preload("Author").    // 预加载作者
 		Preload("Tags").      // ✅ 预加载标签
 		Order("created_at DESC").
 		Offset(offset).
@@ -125,8 +202,23 @@ func (r *ArticleRepository) ListWithAssociations(ctx context.Context, page, page
 	return articles, total, nil
 }
 
-// UpdateWithTags 更新文章并关联标签
-func (r *ArticleRepository) UpdateWithTags(ctx context.Context, article *model.Article, tagIDs []uint) error {
+// CreateWithTags 创建文章并关联标签（tagNames 中不存在的标签在同一事务中创建）
+func (r *ArticleRepository) CreateWithTags(ctx context.Context, article *model.Article, tagIDs []uint, tagNames []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 查找或创建标签
+		tags, err := findOrCreateTags(tx, tagIDs, tagNames)
+		if err != nil {
+			return err
+		}
+		
+		// 2. 创建文章并关联标签
+		article.Tags = tags
+		return tx.Create(article).Error
+	})
+}
+
+// UpdateWithTags 更新文章并关联标签（tagNames 中不存在的标签在同一事务中创建）
+func (r *ArticleRepository) UpdateWithTags(ctx context.Context, article *model.Article, tagIDs []uint, tagNames []string) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm. DB) error {
 		// 1. 更新文章基本信息
 		if err := tx.Save(article).Error; err != nil {
@@ -139,11 +231,11 @@ func (r *ArticleRepository) UpdateWithTags(ctx context.Context, article *model.A
 		}
 		
 		// 3. 如果有新标签，添加关联
-		if len(tagIDs) > 0 {
-			var tags []model.Tag
-			if err := tx.Where("id IN ? ", tagIDs).Find(&tags).Error; err != nil {
-				return err
-			}
+		tags, err := findOrCreateTags(tx, tagIDs, tagNames)
+		if err != nil {
+			return err
+		}
+		if len(tags) > 0 {
 			if err := tx.Model(article).Association("Tags").Append(tags); err != nil {
 				return err
 			}
@@ -153,14 +245,58 @@ func (r *ArticleRepository) UpdateWithTags(ctx context.Context, article *model.A
 	})
 }
 
-// ListByCategory 根据分类查询文章
-func (r *ArticleRepository) ListByCategory(ctx context.Context, categoryID uint, page, pageSize int) ([]*model.Article, int64, error) {
+// findOrCreateTags 查询标签 ID 对应的标签，并按名称查找标签（不区分大小写，别名解析为对应标签），不存在时创建
+// 返回的标签已去重
+func findOrCreateTags(tx *gorm.DB, tagIDs []uint, tagNames []string) ([]model.Tag, error) {
+	var tags []model.Tag
+	if len(tagIDs) > 0 {
+		if err := tx.Where("id IN ?", tagIDs).Find(&tags).Error; err != nil {
+			return nil, err
+		}
+	}
+	
+	for _, name := range tagNames {
+		var tag model.Tag
+		err := tx.Where("LOWER(name) = LOWER(?)", name).First(&tag).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			var alias model.TagAlias
+			if err = tx.Where("LOWER(alias) = LOWER(?)", name).First(&alias).Error; err == nil {
+				err = tx.First(&tag, alias.TagID).Error
+			}
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// 并发创建同名标签时忽略冲突，重新查询已创建的标签
+			// （使用加锁读：REPEATABLE READ 下普通查询读的是事务快照，看不到其他事务刚提交的标签）
+			if err = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.Tag{Name: name}).Error; err == nil {
+				err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("name = ?", name).First(&tag).Error
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	
+	seen := make(map[uint]bool, len(tags))
+	unique := tags[:0]
+	for _, tag := range tags {
+		if !seen[tag.ID] {
+			seen[tag.ID] = true
+			unique = append(unique, tag)
+		}
+	}
+	return unique, nil
+}
+
+// ListByCategory 根据分类查询文章（categoryIDs 可包含子孙分类）
+func (r *ArticleRepository) ListByCategory(ctx context.Context, categoryIDs []uint, page, pageSize int, filter ArticleFilter) ([]*model.Article, int64, error) {
 	var articles []*model.Article
 	var total int64
 	
 	query := r.db.WithContext(ctx).
 		Model(&model.Article{}).
-		Where("category_id = ?  AND status = ? ", categoryID, 1) // 只查已发布的
+		Where("category_id IN ?  AND status = ? ", categoryIDs, 1) // 只查已发布的
+	query = applyFilter(query, filter)
 	
 	// 统计总数
 	if err := query.Count(&total).Error; err != nil {
@@ -171,6 +307,36 @@ func (r *ArticleRepository) ListByCategory(ctx context.Context, categoryID uint,
 	offset := (page - 1) * pageSize
 	err := query. 
 		Preload("Category").
+		Preload("Author").
+		Preload("Tags").
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(pageSize).
+		Find(&articles).Error
+	
+	return articles, total, err
+}
+
+// ListByAuthor 根据作者查询文章
+func (r *ArticleRepository) ListByAuthor(ctx context.Context, authorID uint, page, pageSize int, filter ArticleFilter) ([]*model.Article, int64, error) {
+	var articles []*model.Article
+	var total int64
+	
+	query := r.db.WithContext(ctx).
+		Model(&model.Article{}).
+		Where("author_id = ? AND status = ?", authorID, 1) // 只查已发布的
+	query = applyFilter(query, filter)
+	
+	// 统计总数
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	
+	// 分页查询
+	offset := (page - 1) * pageSize
+	err := query.
+		Preload("Category").
+		Preload("Author").
 		Preload("Tags").
 		Order("created_at DESC").
 		Offset(offset).
@@ -181,7 +347,7 @@ func (r *ArticleRepository) ListByCategory(ctx context.Context, categoryID uint,
 }
 
 // ListByTag 根据标签查询文章
-func (r *ArticleRepository) ListByTag(ctx context.Context, tagID uint, page, pageSize int) ([]*model.Article, int64, error) {
+func (r *ArticleRepository) ListByTag(ctx context.Context, tagID uint, page, pageSize int, filter ArticleFilter) ([]*model.Article, int64, error) {
 	var articles []*model.Article
 	var total int64
 	
@@ -190,6 +356,7 @@ func (r *ArticleRepository) ListByTag(ctx context.Context, tagID uint, page, pag
 		Model(&model.Article{}).
 		Joins("JOIN article_tags ON articles.id = article_tags.article_id").
 		Where("article_tags. tag_id = ? AND articles.status = ?", tagID, 1)
+	query = applyFilter(query, filter)
 	
 	// 统计总数
 	if err := query.Count(&total).Error; err != nil {
@@ -200,6 +367,7 @@ func (r *ArticleRepository) ListByTag(ctx context.Context, tagID uint, page, pag
 	offset := (page - 1) * pageSize
 	err := query. 
 		Preload("Category").
+		Preload("Author").
 		Preload("Tags").
 		Order("articles.created_at DESC").
 		Offset(offset).
@@ -210,7 +378,7 @@ func (r *ArticleRepository) ListByTag(ctx context.Context, tagID uint, page, pag
 }
 
 // Search 搜索文章（标题或内容）
-func (r *ArticleRepository) Search(ctx context.Context, keyword string, page, pageSize int) ([]*model.Article, int64, error) {
+func (r *ArticleRepository) Search(ctx context.Context, keyword string, page, pageSize int, filter ArticleFilter) ([]*model.Article, int64, error) {
 	var articles []*model.Article
 	var total int64
 	
@@ -218,7 +386,8 @@ func (r *ArticleRepository) Search(ctx context.Context, keyword string, page, pa
 	searchPattern := "%" + keyword + "%"
 	query := r.db.WithContext(ctx).
 		Model(&model.Article{}).
-		Where("(title LIKE ? OR content LIKE ?) AND status = ?", searchPattern, searchPattern, 1)
+		Where("(title LIKE ? OR (content LIKE ? AND is_protected = ?)) AND status = ?", searchPattern, searchPattern, false, 1) // 加密文章不参与正文匹配
+	query = applyFilter(query, filter)
 	
 	// 统计总数
 	if err := query.Count(&total).Error; err != nil {
@@ -229,6 +398,7 @@ func (r *ArticleRepository) Search(ctx context.Context, keyword string, page, pa
 	offset := (page - 1) * pageSize
 	err := query.
 		Preload("Category").
+		Preload("Author").
 		Preload("Tags").
 		Order("created_at DESC").
 		Offset(offset).
@@ -236,4 +406,63 @@ func (r *ArticleRepository) Search(ctx context.Context, keyword string, page, pa
 		Find(&articles).Error
 	
 	return articles, total, err
-}
\ No newline at end of file
+}
+
+// ListBySlug 查询使用指定别名的所有文章（各语言版本）
+func (r *ArticleRepository) ListBySlug(ctx context.Context, slug string) ([]*model.Article, error) {
+	var articles []*model.Article
+	err := r.db.WithContext(ctx).
+		Where("slug = ?", slug).
+		Order("id ASC").
+		Find(&articles).Error
+	return articles, err
+}
+
+// ListByTranslationGroup 查询翻译组内的所有文章
+func (r *ArticleRepository) ListByTranslationGroup(ctx context.Context, groupID uint) ([]*model.Article, error) {
+	var articles []*model.Article
+	err := r.db.WithContext(ctx).
+		Where("translation_group_id = ? OR id = ?", groupID, groupID).
+		Order("id ASC").
+		Find(&articles).Error
+	return articles, err
+}
+
+// ListTranslations 查询文章的其他已发布语言版本
+func (r *ArticleRepository) ListTranslations(ctx context.Context, groupID, excludeID uint) ([]model.ArticleTranslation, error) {
+	var translations []model.ArticleTranslation
+	err := r.db.WithContext(ctx).
+		Model(&model.Article{}).
+		Select("id, language, title, slug").
+		Where("(translation_group_id = ? OR id = ?) AND id <> ? AND status = ?", groupID, groupID, excludeID, 1).
+		Order("language ASC").
+		Scan(&translations).Error
+	return translations, err
+}
+
+// applyFilter 应用通用筛选条件
+func applyFilter(query *gorm.DB, filter ArticleFilter) *gorm.DB {
+	if filter.Language != "" {
+		query = query.Where("articles.language = ?", filter.Language)
+	}
+	// 自定义字段筛选（key 已由 Service 层校验为已定义的字段名）
+	// 加密文章的自定义字段不公开，不参与筛选，避免通过筛选结果推测字段值
+	for key, value := range filter.Metadata {
+		query = query.Where("JSON_UNQUOTE(JSON_EXTRACT(articles.metadata, ?)) = ?", "$."+key, value)
+	}
+	if len(filter.Metadata) > 0 {
+		query = query.Where("articles.is_protected = ?", false)
+	}
+	return query
+}
+
+// ListPublishedContent 查询所有已发布文章的正文和封面（用于链接检查等后台扫描）
+func (r *ArticleRepository) ListPublishedContent(ctx context.Context) ([]*model.Article, error) {
+	var articles []*model.Article
+	err := r.db.WithContext(ctx).
+		Select("id, title, content, cover_img").
+		Where("status = ?", 1).
+		Order("id ASC").
+		Find(&articles).Error
+	return articles, err
+}
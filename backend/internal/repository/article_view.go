@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/zyy125/my-blog/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// DailyViewCount 某日的浏览量
+type DailyViewCount struct {
+	Date  time.Time `json:"-"`
+	Views int64     `json:"views"`
+}
+
+// ArticleViewCount 文章在某时间段内的浏览量
+type ArticleViewCount struct {
+	ArticleID uint   `json:"article_id"`
+	Title     string `json:"title"`
+	Views     int64  `json:"views"`
+}
+
+// ReferrerCount 来源域名的浏览量
+type ReferrerCount struct {
+	Referrer string `json:"referrer"`
+	Views    int64  `json:"views"`
+}
+
+// CampaignCount 推广活动的浏览量
+type CampaignCount struct {
+	UTMSource   string `json:"utm_source"`
+	UTMMedium   string `json:"utm_medium"`
+	UTMCampaign string `json:"utm_campaign"`
+	Views       int64  `json:"views"`
+}
+
+// ArticleViewRepository 文章浏览量统计数据访问层
+type ArticleViewRepository struct {
+	db *gorm.DB
+}
+
+// NewArticleViewRepository 创建浏览量统计仓库实例
+func NewArticleViewRepository(db *gorm.DB) *ArticleViewRepository {
+	return &ArticleViewRepository{db: db}
+}
+
+// DailyByArticle 查询文章在日期区间内的每日浏览量
+func (r *ArticleViewRepository) DailyByArticle(ctx context.Context, articleID uint, start, end time.Time) ([]DailyViewCount, error) {
+	var results []DailyViewCount
+	err := r.db.WithContext(ctx).
+		Model(&model.ArticleViewDaily{}).
+		Select("date, views").
+		Where("article_id = ? AND date BETWEEN ? AND ?", articleID, start, end).
+		Order("date ASC").
+		Scan(&results).Error
+	return results, err
+}
+
+// DailyTotal 查询全站在日期区间内的每日浏览量
+func (r *ArticleViewRepository) DailyTotal(ctx context.Context, start, end time.Time) ([]DailyViewCount, error) {
+	var results []DailyViewCount
+	err := r.db.WithContext(ctx).
+		Model(&model.ArticleViewDaily{}).
+		Select("date, SUM(views) AS views").
+		Where("date BETWEEN ? AND ?", start, end).
+		Group("date").
+		Order("date ASC").
+		Scan(&results).Error
+	return results, err
+}
+
+// TopArticles 查询日期区间内浏览量最高的文章
+func (r *ArticleViewRepository) TopArticles(ctx context.Context, start, end time.Time, limit int) ([]ArticleViewCount, error) {
+	var results []ArticleViewCount
+	err := r.db.WithContext(ctx).
+		Model(&model.ArticleViewDaily{}).
+		Select("article_view_daily.article_id, articles.title, SUM(article_view_daily.views) AS views").
+		Joins("JOIN articles ON articles.id = article_view_daily.article_id").
+		Where("article_view_daily.date BETWEEN ? AND ?", start, end).
+		Group("article_view_daily.article_id, articles.title").
+		Order("views DESC").
+		Limit(limit).
+		Scan(&results).Error
+	return results, err
+}
+
+// TopReferrers 查询日期区间内浏览量最高的来源域名（articleID 为 nil 时统计全站）
+func (r *ArticleViewRepository) TopReferrers(ctx context.Context, articleID *uint, start, end time.Time, limit int) ([]ReferrerCount, error) {
+	var results []ReferrerCount
+
+	query := r.db.WithContext(ctx).
+		Model(&model.ArticleReferrerDaily{}).
+		Where("date BETWEEN ? AND ?", start, end)
+	if articleID != nil {
+		query = query.Where("article_id = ?", *articleID)
+	}
+
+	err := query.
+		Select("referrer, SUM(views) AS views").
+		Group("referrer").
+		Order("views DESC").
+		Limit(limit).
+		Scan(&results).Error
+	return results, err
+}
+
+// TopCampaigns 查询日期区间内浏览量最高的推广活动（articleID 为 nil 时统计全站）
+func (r *ArticleViewRepository) TopCampaigns(ctx context.Context, articleID *uint, start, end time.Time, limit int) ([]CampaignCount, error) {
+	var results []CampaignCount
+
+	query := r.db.WithContext(ctx).
+		Model(&model.ArticleReferrerDaily{}).
+		Where("date BETWEEN ? AND ?", start, end).
+		Where("utm_source <> '' OR utm_medium <> '' OR utm_campaign <> ''") // 只统计带推广参数的浏览
+	if articleID != nil {
+		query = query.Where("article_id = ?", *articleID)
+	}
+
+	err := query.
+		Select("utm_source, utm_medium, utm_campaign, SUM(views) AS views").
+		Group("utm_source, utm_medium, utm_campaign").
+		Order("views DESC").
+		Limit(limit).
+		Scan(&results).Error
+	return results, err
+}
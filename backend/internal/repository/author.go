@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zyy125/my-blog/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// AuthorRepository 作者数据访问层
+type AuthorRepository struct {
+	db *gorm.DB
+}
+
+// NewAuthorRepository 创建作者仓库实例
+func NewAuthorRepository(db *gorm.DB) *AuthorRepository {
+	return &AuthorRepository{db: db}
+}
+
+// Create 创建作者
+func (r *AuthorRepository) Create(ctx context.Context, author *model.Author) error {
+	return r.db.WithContext(ctx).Create(author).Error
+}
+
+// GetByID 根据ID查询作者
+func (r *AuthorRepository) GetByID(ctx context.Context, id uint) (*model.Author, error) {
+	var author model.Author
+	err := r.db.WithContext(ctx).First(&author, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &author, nil
+}
+
+// GetByUsername 根据登录名查询作者
+func (r *AuthorRepository) GetByUsername(ctx context.Context, username string) (*model.Author, error) {
+	var author model.Author
+	err := r.db.WithContext(ctx).Where("username = ?", username).First(&author).Error
+	if err != nil {
+		return nil, err
+	}
+	return &author, nil
+}
+
+// List 查询所有作者
+func (r *AuthorRepository) List(ctx context.Context) ([]*model.Author, error) {
+	var authors []*model.Author
+	err := r.db.WithContext(ctx).Order("created_at ASC").Find(&authors).Error
+	return authors, err
+}
+
+// Update 更新作者
+func (r *AuthorRepository) Update(ctx context.Context, author *model.Author) error {
+	return r.db.WithContext(ctx).Save(author).Error
+}
+
+// Delete 删除作者
+func (r *AuthorRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&model.Author{}, id).Error
+}
+
+// CountArticles 统计作者的文章数量
+func (r *AuthorRepository) CountArticles(ctx context.Context, authorID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&model.Article{}).
+		Where("author_id = ?", authorID).
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zyy125/my-blog/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// BrokenLinkRepository 死链检查结果数据访问层
+type BrokenLinkRepository struct {
+	db *gorm.DB
+}
+
+// NewBrokenLinkRepository 创建死链仓库实例
+func NewBrokenLinkRepository(db *gorm.DB) *BrokenLinkRepository {
+	return &BrokenLinkRepository{db: db}
+}
+
+// ReplaceAll 用新一轮检查结果替换全部记录
+func (r *BrokenLinkRepository) ReplaceAll(ctx context.Context, links []model.BrokenLink) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 清除上一轮结果
+		if err := tx.Where("1 = 1").Delete(&model.BrokenLink{}).Error; err != nil {
+			return err
+		}
+
+		// 2. 写入本轮结果
+		if len(links) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(links, 100).Error
+	})
+}
+
+// List 查询死链记录（articleID 为 nil 时查询全部，先文章后页面），附带文章或页面标题
+func (r *BrokenLinkRepository) List(ctx context.Context, articleID *uint) ([]*model.BrokenLink, error) {
+	var links []*model.BrokenLink
+
+	query := r.db.WithContext(ctx).
+		Select("broken_links.*, COALESCE(articles.title, pages.title) AS title").
+		Joins("LEFT JOIN articles ON articles.id = broken_links.article_id").
+		Joins("LEFT JOIN pages ON pages.id = broken_links.page_id")
+	if articleID != nil {
+		query = query.Where("broken_links.article_id = ?", *articleID)
+	}
+
+	err := query.Order("broken_links.page_id ASC, broken_links.article_id ASC, broken_links.id ASC").Find(&links).Error
+	return links, err
+}
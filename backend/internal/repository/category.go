@@ -17,8 +17,9 @@ func NewCategoryRepository(db *gorm.DB) *CategoryRepository {
 }
 
 // Create 创建分类
+// 名称或 URL 别名重复时返回 gorm.ErrDuplicatedKey
 func (r *CategoryRepository) Create(ctx context.Context, category *model. Category) error {
-	return r.db.WithContext(ctx).Create(category).Error
+	return translateError(r.db, r.db.WithContext(ctx).Create(category).Error)
 }
 
 // GetByID 根据ID查询分类
@@ -41,16 +42,27 @@ func (r *CategoryRepository) GetByName(ctx context.Context, name string) (*model
 	return &category, nil
 }
 
-// List 查询所有分类
+// GetBySlug 根据别名查询分类
+func (r *CategoryRepository) GetBySlug(ctx context.Context, slug string) (*model.Category, error) {
+	var category model.Category
+	err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&category).Error
+	if err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// List 查询所有分类（按同级排序和创建时间排序）
 func (r *CategoryRepository) List(ctx context.Context) ([]*model.Category, error) {
 	var categories []*model. Category
-	err := r.db.WithContext(ctx).Order("created_at DESC").Find(&categories).Error
+	err := r.db.WithContext(ctx).Order("sort_order ASC, created_at DESC").Find(&categories).Error
 	return categories, err
 }
 
-// Update 更新分类
-func (r *CategoryRepository) Update(ctx context.Context, category *model.Category) error {
-	return r.db.WithContext(ctx).Save(category).Error
+// Update 更新分类的指定列（零值也会写入）
+// 名称或 URL 别名重复时返回 gorm.ErrDuplicatedKey
+func (r *CategoryRepository) Update(ctx context.Context, category *model.Category, columns []string) error {
+	return translateError(r.db, r.db.WithContext(ctx).Model(category).Select(columns).Updates(category).Error)
 }
 
 // Delete 删除分类
@@ -58,6 +70,28 @@ func (r *CategoryRepository) Delete(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Delete(&model.Category{}, id).Error
 }
 
+// MoveAndDelete 在同一事务中将分类下的文章和直接子分类移到目标分类（targetID 为 nil 时变为未分类/顶级分类），然后删除该分类
+func (r *CategoryRepository) MoveAndDelete(ctx context.Context, id uint, targetID *uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 转移文章
+		if err := tx.Model(&model.Article{}).
+			Where("category_id = ?", id).
+			Update("category_id", targetID).Error; err != nil {
+			return err
+		}
+		
+		// 2. 转移子分类
+		if err := tx.Model(&model.Category{}).
+			Where("parent_id = ?", id).
+			Update("parent_id", targetID).Error; err != nil {
+			return err
+		}
+		
+		// 3. 删除分类
+		return tx.Delete(&model.Category{}, id).Error
+	})
+}
+
 // CountArticles 统计分类下的文章数量
 func (r *CategoryRepository) CountArticles(ctx context.Context, categoryID uint) (int64, error) {
 	var count int64
@@ -68,6 +102,16 @@ func (r *CategoryRepository) CountArticles(ctx context.Context, categoryID uint)
 	return count, err
 }
 
+// CountChildren 统计直接子分类数量
+func (r *CategoryRepository) CountChildren(ctx context.Context, id uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&model.Category{}).
+		Where("parent_id = ?", id).
+		Count(&count).Error
+	return count, err
+}
+
 // ListWithArticleCount 查询分类列表（带文章数量统计）
 func (r *CategoryRepository) ListWithArticleCount(ctx context.Context) ([]map[string]interface{}, error) {
 	var results []map[string]interface{}
@@ -77,8 +121,19 @@ func (r *CategoryRepository) ListWithArticleCount(ctx context.Context) ([]map[st
 		Select("categories.*, COUNT(articles.id) as article_count").
 		Joins("LEFT JOIN articles ON articles.category_id = categories.id").
 		Group("categories.id").
-		Order("categories.created_at DESC").
+		Order("categories.sort_order ASC, categories.created_at DESC").
 		Scan(&results).Error
 	
 	return results, err
+}
+
+// translateError 将数据库驱动的错误转换为 gorm 的通用错误（如唯一约束冲突转换为 gorm.ErrDuplicatedKey）
+func translateError(db *gorm.DB, err error) error {
+	if err == nil {
+		return nil
+	}
+	if translator, ok := db.Dialector.(gorm.ErrorTranslator); ok {
+		return translator.Translate(err)
+	}
+	return err
 }
\ No newline at end of file
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zyy125/my-blog/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// CustomFieldRepository 自定义字段数据访问层
+type CustomFieldRepository struct {
+	db *gorm.DB
+}
+
+// NewCustomFieldRepository 创建自定义字段仓库实例
+func NewCustomFieldRepository(db *gorm.DB) *CustomFieldRepository {
+	return &CustomFieldRepository{db: db}
+}
+
+// Create 创建自定义字段
+func (r *CustomFieldRepository) Create(ctx context.Context, field *model.CustomField) error {
+	return r.db.WithContext(ctx).Create(field).Error
+}
+
+// GetByID 根据ID查询自定义字段
+func (r *CustomFieldRepository) GetByID(ctx context.Context, id uint) (*model.CustomField, error) {
+	var field model.CustomField
+	err := r.db.WithContext(ctx).First(&field, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &field, nil
+}
+
+// GetByName 根据字段名查询自定义字段
+func (r *CustomFieldRepository) GetByName(ctx context.Context, name string) (*model.CustomField, error) {
+	var field model.CustomField
+	err := r.db.WithContext(ctx).Where("name = ?", name).First(&field).Error
+	if err != nil {
+		return nil, err
+	}
+	return &field, nil
+}
+
+// List 查询所有自定义字段
+func (r *CustomFieldRepository) List(ctx context.Context) ([]*model.CustomField, error) {
+	var fields []*model.CustomField
+	err := r.db.WithContext(ctx).Order("id ASC").Find(&fields).Error
+	return fields, err
+}
+
+// Update 更新自定义字段
+func (r *CustomFieldRepository) Update(ctx context.Context, field *model.CustomField) error {
+	return r.db.WithContext(ctx).Save(field).Error
+}
+
+// Delete 删除自定义字段，同时从所有文章的元数据中移除该字段
+func (r *CustomFieldRepository) Delete(ctx context.Context, field *model.CustomField) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 移除文章元数据中的字段
+		path := "$." + field.Name
+		err := tx.Model(&model.Article{}).
+			Where("JSON_CONTAINS_PATH(metadata, 'one', ?)", path).
+			UpdateColumn("metadata", gorm.Expr("JSON_REMOVE(metadata, ?)", path)).Error
+		if err != nil {
+			return err
+		}
+
+		// 2. 删除字段定义
+		return tx.Delete(&model.CustomField{}, field.ID).Error
+	})
+}
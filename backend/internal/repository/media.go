@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/zyy125/my-blog/backend/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ContentSource 可能引用上传文件的内容（文章正文/封面/自定义字段、页面正文、作者头像、分类和标签的封面/介绍）
+type ContentSource struct {
+	Type  string
+	ID    uint
+	Title string
+	Text  string
+}
+
+// MonthlyUsage 某月上传文件的用量
+type MonthlyUsage struct {
+	Month string `json:"month"` // 月份（YYYY-MM）
+	Files int64  `json:"files"`
+	Bytes int64  `json:"bytes"`
+}
+
+// MimeTypeUsage 某种文件类型的用量
+type MimeTypeUsage struct {
+	MimeType string `json:"mime_type"`
+	Files    int64  `json:"files"`
+	Bytes    int64  `json:"bytes"`
+}
+
+// MediaRepository 媒体文件数据访问层
+type MediaRepository struct {
+	db *gorm.DB
+}
+
+// NewMediaRepository 创建媒体仓库实例
+func NewMediaRepository(db *gorm.DB) *MediaRepository {
+	return &MediaRepository{db: db}
+}
+
+// Create 创建媒体记录
+func (r *MediaRepository) Create(ctx context.Context, media *model.Media) error {
+	return r.db.WithContext(ctx).Create(media).Error
+}
+
+// CreateOrAddRef 创建媒体记录；相同路径的记录已存在时引用计数加一
+// 返回数据库中的最新记录
+func (r *MediaRepository) CreateOrAddRef(ctx context.Context, media *model.Media) (*model.Media, error) {
+	db := r.db.WithContext(ctx)
+	err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "path"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"ref_count": gorm.Expr("ref_count + 1")}),
+	}).Create(media).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var saved model.Media
+	if err := db.Where("path = ?", media.Path).First(&saved).Error; err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
+
+// ReleaseRef 引用计数大于 1 时减一，返回是否减少成功（为 false 时说明是最后一次引用）
+func (r *MediaRepository) ReleaseRef(ctx context.Context, id uint) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&model.Media{}).
+		Where("id = ? AND ref_count > 1", id).
+		UpdateColumn("ref_count", gorm.Expr("ref_count - 1"))
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetByID 根据ID查询媒体
+func (r *MediaRepository) GetByID(ctx context.Context, id uint) (*model.Media, error) {
+	var media model.Media
+	err := r.db.WithContext(ctx).First(&media, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &media, nil
+}
+
+// GetByPath 根据访问路径查询媒体
+func (r *MediaRepository) GetByPath(ctx context.Context, path string) (*model.Media, error) {
+	var media model.Media
+	err := r.db.WithContext(ctx).Where("path = ?", path).First(&media).Error
+	if err != nil {
+		return nil, err
+	}
+	return &media, nil
+}
+
+// IncrementDownloads 下载次数加一
+func (r *MediaRepository) IncrementDownloads(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Model(&model.Media{}).
+		Where("id = ?", id).
+		UpdateColumn("downloads", gorm.Expr("downloads + 1")).Error
+}
+
+// List 分页查询媒体（可按文件名/路径关键词和 MIME 前缀筛选）
+func (r *MediaRepository) List(ctx context.Context, page, pageSize int, keyword, mimePrefix string) ([]*model.Media, int64, error) {
+	var list []*model.Media
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&model.Media{})
+	if keyword != "" {
+		query = query.Where("file_name LIKE ? OR path LIKE ?", "%"+keyword+"%", "%"+keyword+"%")
+	}
+	if mimePrefix != "" {
+		query = query.Where("mime_type LIKE ?", mimePrefix+"%")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err := query.Order("uploaded_at DESC, id DESC").
+		Offset(offset).
+		Limit(pageSize).
+		Find(&list).Error
+
+	return list, total, err
+}
+
+// ListPaths 查询所有媒体的路径和上传时间（用于孤立文件清理）
+func (r *MediaRepository) ListPaths(ctx context.Context) ([]*model.Media, error) {
+	var list []*model.Media
+	err := r.db.WithContext(ctx).Select("id, path, size, variants, uploaded_at").Find(&list).Error
+	return list, err
+}
+
+// UsageByMonth 按上传月份统计 since 之后上传的文件数和大小（不含衍生版本）
+func (r *MediaRepository) UsageByMonth(ctx context.Context, since time.Time) ([]MonthlyUsage, error) {
+	var results []MonthlyUsage
+	err := r.db.WithContext(ctx).
+		Model(&model.Media{}).
+		Select("DATE_FORMAT(uploaded_at, '%Y-%m') AS month, COUNT(*) AS files, SUM(size) AS bytes").
+		Where("uploaded_at >= ?", since).
+		Group("month").
+		Order("month ASC").
+		Scan(&results).Error
+	return results, err
+}
+
+// UsageByMimeType 按 MIME 类型统计文件数和大小（不含衍生版本）
+func (r *MediaRepository) UsageByMimeType(ctx context.Context) ([]MimeTypeUsage, error) {
+	var results []MimeTypeUsage
+	err := r.db.WithContext(ctx).
+		Model(&model.Media{}).
+		Select("mime_type, COUNT(*) AS files, SUM(size) AS bytes").
+		Group("mime_type").
+		Order("bytes DESC").
+		Scan(&results).Error
+	return results, err
+}
+
+// Largest 查询最大的文件
+func (r *MediaRepository) Largest(ctx context.Context, limit int) ([]*model.Media, error) {
+	var list []*model.Media
+	err := r.db.WithContext(ctx).
+		Order("size DESC, id DESC").
+		Limit(limit).
+		Find(&list).Error
+	return list, err
+}
+
+// Delete 删除媒体记录
+func (r *MediaRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&model.Media{}, id).Error
+}
+
+// DeleteByPaths 按路径批量删除媒体记录
+func (r *MediaRepository) DeleteByPaths(ctx context.Context, paths []string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Where("path IN ?", paths).Delete(&model.Media{}).Error
+}
+
+// ListContentSources 查询可能引用上传文件的内容（包括草稿）
+// keywords 非空时只查询包含其中任一关键词（文件地址）的内容，为空时查询全部
+func (r *MediaRepository) ListContentSources(ctx context.Context, keywords []string) ([]ContentSource, error) {
+	var sources []ContentSource
+
+	// 1. 文章正文、封面和自定义字段
+	var articles []model.Article
+	query := matchAny(r.db.WithContext(ctx).Select("id, title, content, cover_img, metadata"), keywords, "content", "cover_img", "metadata")
+	if err := query.Find(&articles).Error; err != nil {
+		return nil, err
+	}
+	for _, a := range articles {
+		text := a.Content + "\n" + a.CoverImg
+		if len(a.Metadata) > 0 {
+			metadata, err := a.Metadata.Value()
+			if err != nil {
+				return nil, err
+			}
+			text += "\n" + metadata.(string)
+		}
+		sources = append(sources, ContentSource{Type: "article", ID: a.ID, Title: a.Title, Text: text})
+	}
+
+	// 2. 独立页面正文
+	var pages []model.Page
+	query = matchAny(r.db.WithContext(ctx).Select("id, title, content"), keywords, "content")
+	if err := query.Find(&pages).Error; err != nil {
+		return nil, err
+	}
+	for _, p := range pages {
+		sources = append(sources, ContentSource{Type: "page", ID: p.ID, Title: p.Title, Text: p.Content})
+	}
+
+	// 3. 作者头像
+	var authors []model.Author
+	query = matchAny(r.db.WithContext(ctx).Select("id, name, avatar").Where("avatar <> ''"), keywords, "avatar")
+	if err := query.Find(&authors).Error; err != nil {
+		return nil, err
+	}
+	for _, a := range authors {
+		sources = append(sources, ContentSource{Type: "author", ID: a.ID, Title: a.Name, Text: a.Avatar})
+	}
+
+	// 4. 分类和标签的封面、介绍
+	var categories []model.Category
+	query = matchAny(r.db.WithContext(ctx).Select("id, name, cover_img, intro").Where("cover_img <> '' OR intro <> ''"), keywords, "cover_img", "intro")
+	if err := query.Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	for _, c := range categories {
+		sources = append(sources, ContentSource{Type: "category", ID: c.ID, Title: c.Name, Text: c.Intro + "\n" + c.CoverImg})
+	}
+	var tags []model.Tag
+	query = matchAny(r.db.WithContext(ctx).Select("id, name, cover_img, intro").Where("cover_img <> '' OR intro <> ''"), keywords, "cover_img", "intro")
+	if err := query.Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	for _, t := range tags {
+		sources = append(sources, ContentSource{Type: "tag", ID: t.ID, Title: t.Name, Text: t.Intro + "\n" + t.CoverImg})
+	}
+
+	return sources, nil
+}
+
+// matchAny 限定任一字段包含任一关键词（keywords 为空时不限定）
+func matchAny(query *gorm.DB, keywords []string, columns ...string) *gorm.DB {
+	if len(keywords) == 0 {
+		return query
+	}
+	var conds []string
+	var args []interface{}
+	for _, column := range columns {
+		for _, keyword := range keywords {
+			conds = append(conds, column+" LIKE ?")
+			args = append(args, "%"+likeEscaper.Replace(keyword)+"%")
+		}
+	}
+	return query.Where("("+strings.Join(conds, " OR ")+")", args...)
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zyy125/my-blog/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// PageRepository 独立页面数据访问层
+type PageRepository struct {
+	db *gorm.DB
+}
+
+// NewPageRepository 创建页面仓库实例
+func NewPageRepository(db *gorm.DB) *PageRepository {
+	return &PageRepository{db: db}
+}
+
+// Create 创建页面
+func (r *PageRepository) Create(ctx context.Context, page *model.Page) error {
+	return r.db.WithContext(ctx).Create(page).Error
+}
+
+// GetByID 根据ID查询页面
+func (r *PageRepository) GetByID(ctx context.Context, id uint) (*model.Page, error) {
+	var page model.Page
+	err := r.db.WithContext(ctx).First(&page, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// GetBySlug 根据别名查询页面
+func (r *PageRepository) GetBySlug(ctx context.Context, slug string) (*model.Page, error) {
+	var page model.Page
+	err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&page).Error
+	if err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// List 查询页面列表（按菜单顺序，status 为 nil 时查询全部）
+func (r *PageRepository) List(ctx context.Context, status *int8) ([]*model.Page, error) {
+	var pages []*model.Page
+
+	query := r.db.WithContext(ctx).Model(&model.Page{})
+	if status != nil {
+		query = query.Where("status = ?", *status)
+	}
+
+	err := query.Order("menu_order ASC, id ASC").Find(&pages).Error
+	return pages, err
+}
+
+// ListPublishedContent 查询所有已发布页面的正文（死链检查用）
+func (r *PageRepository) ListPublishedContent(ctx context.Context) ([]*model.Page, error) {
+	var pages []*model.Page
+	err := r.db.WithContext(ctx).
+		Select("id, title, content").
+		Where("status = ?", 1).
+		Order("id ASC").
+		Find(&pages).Error
+	return pages, err
+}
+
+// ListMenu 查询已发布页面的菜单信息（不含正文）
+func (r *PageRepository) ListMenu(ctx context.Context) ([]*model.Page, error) {
+	var pages []*model.Page
+	err := r.db.WithContext(ctx).
+		Select("id, title, slug, menu_order").
+		Where("status = ?", 1).
+		Order("menu_order ASC, id ASC").
+		Find(&pages).Error
+	return pages, err
+}
+
+// Update 更新页面
+func (r *PageRepository) Update(ctx context.Context, page *model.Page) error {
+	return r.db.WithContext(ctx).Save(page).Error
+}
+
+// Delete 删除页面
+func (r *PageRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 清除页面的死链检查记录
+		if err := tx.Where("page_id = ?", id).Delete(&model.BrokenLink{}).Error; err != nil {
+			return err
+		}
+
+		// 2. 删除页面
+		return tx.Delete(&model.Page{}, id).Error
+	})
+}
+
+// CreateFromArticle 把文章转换为页面（创建页面并删除原文章及其标签、评论、表态和浏览统计）
+func (r *PageRepository) CreateFromArticle(ctx context.Context, page *model.Page, articleID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 创建页面
+		if err := tx.Create(page).Error; err != nil {
+			return err
+		}
+
+		// 2. 清除文章的标签关联
+		article := &model.Article{ID: articleID}
+		if err := tx.Model(article).Association("Tags").Clear(); err != nil {
+			return err
+		}
+
+		// 3. 清除依附于文章的评论、表态、浏览统计和死链记录，避免残留在统计中
+		for _, related := range []interface{}{
+			&model.Comment{},
+			&model.Reaction{},
+			&model.ArticleViewDaily{},
+			&model.ArticleReferrerDaily{},
+			&model.BrokenLink{},
+		} {
+			if err := tx.Where("article_id = ?", articleID).Delete(related).Error; err != nil {
+				return err
+			}
+		}
+
+		// 4. 删除文章
+		return tx.Delete(&model.Article{}, articleID).Error
+	})
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zyy125/my-blog/backend/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReactionCount 表态数量统计
+type ReactionCount struct {
+	ArticleID uint   `json:"article_id,omitempty"`
+	Type      string `json:"type"`
+	Count     int64  `json:"count"`
+}
+
+// ReactionRepository 文章表态数据访问层
+type ReactionRepository struct {
+	db *gorm.DB
+}
+
+// NewReactionRepository 创建表态仓库实例
+func NewReactionRepository(db *gorm.DB) *ReactionRepository {
+	return &ReactionRepository{db: db}
+}
+
+// Create 创建表态（同一访客重复表态时忽略）
+func (r *ReactionRepository) Create(ctx context.Context, reaction *model.Reaction) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(reaction).Error
+}
+
+// Delete 删除访客的表态
+func (r *ReactionRepository) Delete(ctx context.Context, articleID uint, reactionType, fingerprint string) error {
+	return r.db.WithContext(ctx).
+		Where("article_id = ? AND type = ? AND fingerprint = ?", articleID, reactionType, fingerprint).
+		Delete(&model.Reaction{}).Error
+}
+
+// CountByArticles 批量统计文章的表态数量
+func (r *ReactionRepository) CountByArticles(ctx context.Context, articleIDs []uint) ([]ReactionCount, error) {
+	var results []ReactionCount
+	if len(articleIDs) == 0 {
+		return results, nil
+	}
+
+	err := r.db.WithContext(ctx).
+		Model(&model.Reaction{}).
+		Select("article_id, type, COUNT(*) AS count").
+		Where("article_id IN ?", articleIDs).
+		Group("article_id, type").
+		Scan(&results).Error
+	return results, err
+}
+
+// CountByType 统计全站各类表态数量
+func (r *ReactionRepository) CountByType(ctx context.Context) ([]ReactionCount, error) {
+	var results []ReactionCount
+	err := r.db.WithContext(ctx).
+		Model(&model.Reaction{}).
+		Select("type, COUNT(*) AS count").
+		Group("type").
+		Order("count DESC").
+		Scan(&results).Error
+	return results, err
+}
+
+// ListTypesByFingerprint 查询访客在文章上已有的表态类型
+func (r *ReactionRepository) ListTypesByFingerprint(ctx context.Context, articleID uint, fingerprint string) ([]string, error) {
+	var types []string
+	err := r.db.WithContext(ctx).
+		Model(&model.Reaction{}).
+		Where("article_id = ? AND fingerprint = ?", articleID, fingerprint).
+		Pluck("type", &types).Error
+	return types, err
+}
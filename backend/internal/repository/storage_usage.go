@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zyy125/my-blog/backend/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// storageUsageID 存储用量汇总行的 ID
+const storageUsageID = 1
+
+// StorageUsageRepository 存储用量仓库（多实例共享同一份用量，配额在数据库中原子地检查和占用）
+type StorageUsageRepository struct {
+	db *gorm.DB
+}
+
+// NewStorageUsageRepository 创建存储用量仓库实例
+func NewStorageUsageRepository(db *gorm.DB) *StorageUsageRepository {
+	return &StorageUsageRepository{db: db}
+}
+
+// Loaded 是否已登记过存储中的文件
+func (r *StorageUsageRepository) Loaded(ctx context.Context) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&model.StorageUsage{}).Where("id = ?", storageUsageID).Count(&count).Error
+	return count > 0, err
+}
+
+// Load 登记存储中已有的文件（objects 为对象键 -> 大小）；其他实例已登记过时忽略
+func (r *StorageUsageRepository) Load(ctx context.Context, objects map[string]int64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 创建汇总行，已存在说明其他实例已完成登记
+		usage := model.StorageUsage{ID: storageUsageID}
+		for _, size := range objects {
+			usage.Bytes += size
+			usage.Files++
+		}
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&usage)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		// 2. 登记每个文件
+		rows := make([]model.StorageObject, 0, len(objects))
+		for key, size := range objects {
+			rows = append(rows, model.StorageObject{ObjectKey: key, Size: size})
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(rows, 500).Error
+	})
+}
+
+// Reserve 登记对象键并占用 size 字节
+// 键已登记（文件已存在或正被其他请求写入）时返回 reserved=false；quota 大于 0 且剩余空间不足时返回 exceeded=true
+func (r *StorageUsageRepository) Reserve(ctx context.Context, key string, size, quota int64) (reserved, exceeded bool, err error) {
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 登记对象键（同一个键的并发请求在主键上排队，只有一个能登记成功）
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.StorageObject{ObjectKey: key, Size: size})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		// 2. 按条件累加用量，超过配额时不更新
+		query := tx.Model(&model.StorageUsage{}).Where("id = ?", storageUsageID)
+		if quota > 0 {
+			query = query.Where("bytes + ? <= ?", size, quota)
+		}
+		result = query.Updates(map[string]interface{}{
+			"bytes": gorm.Expr("bytes + ?", size),
+			"files": gorm.Expr("files + 1"),
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			exceeded = true
+			return errQuotaRollback
+		}
+		reserved = true
+		return nil
+	})
+	if errors.Is(err, errQuotaRollback) {
+		err = nil
+	}
+	return reserved, exceeded, err
+}
+
+// errQuotaRollback 超过配额时用于回滚事务
+var errQuotaRollback = errors.New("存储空间不足")
+
+// Release 注销对象键并释放其占用的空间（未登记时不报错）
+func (r *StorageUsageRepository) Release(ctx context.Context, key string) error {
+	_, err := r.release(ctx, "object_key = ?", key)
+	return err
+}
+
+// ReleaseStale 对象键在 before 之前登记时注销并释放空间，返回是否已注销
+// 用于回收写入存储前进程退出遗留的登记（否则该键永远无法再次上传，占用的配额也不会释放）
+func (r *StorageUsageRepository) ReleaseStale(ctx context.Context, key string, before time.Time) (bool, error) {
+	return r.release(ctx, "object_key = ? AND created_at < ?", key, before)
+}
+
+// release 注销符合条件的对象键并扣减用量，返回是否已注销
+func (r *StorageUsageRepository) release(ctx context.Context, query string, args ...interface{}) (bool, error) {
+	released := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 锁定登记记录，避免并发注销时重复扣减
+		var object model.StorageObject
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(query, args...).First(&object).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// 2. 删除记录并扣减用量
+		if err := tx.Delete(&object).Error; err != nil {
+			return err
+		}
+		released = true
+		return tx.Model(&model.StorageUsage{}).Where("id = ?", storageUsageID).Updates(map[string]interface{}{
+			"bytes": gorm.Expr("bytes - ?", object.Size),
+			"files": gorm.Expr("files - 1"),
+		}).Error
+	})
+	return released && err == nil, err
+}
+
+// Total 返回已用空间（字节）和文件数
+func (r *StorageUsageRepository) Total(ctx context.Context) (int64, int64, error) {
+	var usage model.StorageUsage
+	err := r.db.WithContext(ctx).Where("id = ?", storageUsageID).Limit(1).Find(&usage).Error
+	return usage.Bytes, usage.Files, err
+}
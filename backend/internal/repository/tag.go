@@ -2,10 +2,20 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"github.com/zyy125/my-blog/backend/internal/model"
 	"gorm.io/gorm"
 )
 
+// TagCount 标签及其已发布文章数
+type TagCount struct {
+	ID    uint
+	Name  string
+	Slug  *string
+	Count int64
+}
+
 // TagRepository 标签数据访问层
 type TagRepository struct {
 	db *gorm.DB
@@ -16,15 +26,16 @@ func NewTagRepository(db *gorm.DB) *TagRepository {
 	return &TagRepository{db: db}
 }
 
-// Create 创建标签
+// Create 创建标签（别名需单独添加）
+// 名称或 URL 别名重复时返回 gorm.ErrDuplicatedKey
 func (r *TagRepository) Create(ctx context.Context, tag *model.Tag) error {
-	return r.db.WithContext(ctx).Create(tag).Error
+	return translateError(r.db, r.db.WithContext(ctx).Omit("Aliases").Create(tag).Error)
 }
 
-// GetByID 根据ID查询标签
+// GetByID 根据ID查询标签（含别名）
 func (r *TagRepository) GetByID(ctx context.Context, id uint) (*model.Tag, error) {
 	var tag model.Tag
-	err := r.db.WithContext(ctx).First(&tag, id).Error
+	err := r.db.WithContext(ctx).Preload("Aliases").First(&tag, id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -41,6 +52,72 @@ func (r *TagRepository) GetByName(ctx context.Context, name string) (*model.Tag,
 	return &tag, nil
 }
 
+// GetBySlug 根据别名查询标签（含别名列表）
+func (r *TagRepository) GetBySlug(ctx context.Context, slug string) (*model.Tag, error) {
+	var tag model.Tag
+	err := r.db.WithContext(ctx).Preload("Aliases").Where("slug = ?", slug).First(&tag).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// GetByNameOrAlias 根据名称或别名查询标准标签（不区分大小写）
+func (r *TagRepository) GetByNameOrAlias(ctx context.Context, name string) (*model.Tag, error) {
+	var tag model.Tag
+	err := r.db.WithContext(ctx).Preload("Aliases").Where("LOWER(name) = LOWER(?)", name).First(&tag).Error
+	if err == nil {
+		return &tag, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	
+	var alias model.TagAlias
+	if err := r.db.WithContext(ctx).Where("LOWER(alias) = LOWER(?)", name).First(&alias).Error; err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, alias.TagID)
+}
+
+// GetAlias 根据别名查询别名记录
+func (r *TagRepository) GetAlias(ctx context.Context, alias string) (*model.TagAlias, error) {
+	var record model.TagAlias
+	err := r.db.WithContext(ctx).Where("alias = ?", alias).First(&record).Error
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// CreateAlias 添加标签别名
+func (r *TagRepository) CreateAlias(ctx context.Context, alias *model.TagAlias) error {
+	return r.db.WithContext(ctx).Create(alias).Error
+}
+
+// DeleteAlias 删除标签的别名
+func (r *TagRepository) DeleteAlias(ctx context.Context, tagID, aliasID uint) (bool, error) {
+	result := r.db.WithContext(ctx).Where("id = ? AND tag_id = ?", aliasID, tagID).Delete(&model.TagAlias{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// Suggest 按前缀查询名称或别名匹配的标签（不区分大小写，名称较短的优先）
+func (r *TagRepository) Suggest(ctx context.Context, prefix string, limit int) ([]*model.Tag, error) {
+	pattern := likeEscaper.Replace(strings.ToLower(prefix)) + "%"
+	
+	var tags []*model.Tag
+	err := r.db.WithContext(ctx).
+		Where("LOWER(name) LIKE ?", pattern).
+		Or("id IN (?)", r.db.Model(&model.TagAlias{}).Select("tag_id").Where("LOWER(alias) LIKE ?", pattern)).
+		Order("CHAR_LENGTH(name) ASC, name ASC").
+		Limit(limit).
+		Find(&tags).Error
+	return tags, err
+}
+
+// likeEscaper 转义 LIKE 通配符
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 // GetByIDs 根据ID列表批量查询标签
 func (r *TagRepository) GetByIDs(ctx context.Context, ids []uint) ([]model.Tag, error) {
 	var tags []model.Tag
@@ -55,9 +132,17 @@ func (r *TagRepository) List(ctx context.Context) ([]*model.Tag, error) {
 	return tags, err
 }
 
-// Update 更新标签
-func (r *TagRepository) Update(ctx context.Context, tag *model. Tag) error {
-	return r.db.WithContext(ctx).Save(tag).Error
+// ListWithAliases 查询所有标签（含别名）
+func (r *TagRepository) ListWithAliases(ctx context.Context) ([]*model.Tag, error) {
+	var tags []*model.Tag
+	err := r.db.WithContext(ctx).Preload("Aliases").Find(&tags).Error
+	return tags, err
+}
+
+// Update 更新标签的指定列（不修改别名，零值也会写入）
+// 名称或 URL 别名重复时返回 gorm.ErrDuplicatedKey
+func (r *TagRepository) Update(ctx context.Context, tag *model. Tag, columns []string) error {
+	return translateError(r.db, r.db.WithContext(ctx).Model(tag).Select(columns).Updates(tag).Error)
 }
 
 // Delete 删除标签
@@ -65,6 +150,67 @@ func (r *TagRepository) Delete(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Delete(&model.Tag{}, id).Error
 }
 
+// Merge 在同一事务中将标签合并到目标标签：
+// 文章关联转移到目标标签（已关联目标标签的文章不重复添加），源标签名称及其别名成为目标标签的别名，然后删除源标签
+func (r *TagRepository) Merge(ctx context.Context, source *model.Tag, targetID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 找出只关联了源标签的文章
+		var sourceArticles, targetArticles []uint
+		if err := tx.Table("article_tags").Where("tag_id = ?", source.ID).Pluck("article_id", &sourceArticles).Error; err != nil {
+			return err
+		}
+		if err := tx.Table("article_tags").Where("tag_id = ?", targetID).Pluck("article_id", &targetArticles).Error; err != nil {
+			return err
+		}
+		tagged := make(map[uint]bool, len(targetArticles))
+		for _, id := range targetArticles {
+			tagged[id] = true
+		}
+		var rows []map[string]interface{}
+		for _, id := range sourceArticles {
+			if !tagged[id] {
+				rows = append(rows, map[string]interface{}{"article_id": id, "tag_id": targetID})
+			}
+		}
+		
+		// 2. 转移文章关联
+		if len(rows) > 0 {
+			if err := tx.Table("article_tags").Create(rows).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Exec("DELETE FROM article_tags WHERE tag_id = ?", source.ID).Error; err != nil {
+			return err
+		}
+		
+		// 3. 转移别名，源标签名称也作为别名保留
+		if err := tx.Model(&model.TagAlias{}).Where("tag_id = ?", source.ID).Update("tag_id", targetID).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&model.TagAlias{TagID: targetID, Alias: source.Name}).Error; err != nil {
+			return err
+		}
+		
+		// 4. 删除源标签
+		return tx.Delete(&model.Tag{}, source.ID).Error
+	})
+}
+
+// ListPublishedCounts 统计每个标签关联的已发布文章数（不含没有已发布文章的标签）
+func (r *TagRepository) ListPublishedCounts(ctx context.Context) ([]TagCount, error) {
+	var results []TagCount
+	
+	err := r.db.WithContext(ctx).
+		Model(&model.Tag{}).
+		Select("tags.id, tags.name, tags.slug, COUNT(articles.id) AS count").
+		Joins("JOIN article_tags ON article_tags.tag_id = tags.id").
+		Joins("JOIN articles ON articles.id = article_tags.article_id AND articles.status = ?", 1).
+		Group("tags.id, tags.name, tags.slug").
+		Scan(&results).Error
+	
+	return results, err
+}
+
 // ListWithArticleCount 查询标签列表（带文章数量统计）
 func (r *TagRepository) ListWithArticleCount(ctx context.Context) ([]map[string]interface{}, error) {
 	var results []map[string]interface{}
@@ -1,16 +1,22 @@
 package router
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
+	"github.com/zyy125/my-blog/backend/config"
 	"github.com/zyy125/my-blog/backend/internal/handler"
 	"github.com/zyy125/my-blog/backend/internal/middleware"
 	"github.com/zyy125/my-blog/backend/internal/pkg/database"
+	"github.com/zyy125/my-blog/backend/internal/pkg/storage"
+	"github.com/zyy125/my-blog/backend/internal/pkg/upload"
 	"github.com/zyy125/my-blog/backend/internal/repository"
 	"github.com/zyy125/my-blog/backend/internal/service"
 )
 
-// SetupRouter 配置路由
-func SetupRouter() *gin.Engine {
+// SetupRouter 配置路由（需先调用 SetupUpload 初始化上传存储）
+// 返回的 cleanup 用于优雅退出时停止后台任务（如写入剩余浏览量）
+func SetupRouter() (*gin.Engine, func()) {
 	r := gin.Default()
 
 	// ========== 初始化依赖 ==========
@@ -19,24 +25,68 @@ func SetupRouter() *gin.Engine {
 	categoryRepo := repository.NewCategoryRepository(database.DB)
 	tagRepo := repository.NewTagRepository(database.DB)
 	commentRepo := repository.NewCommentRepository(database.DB)
+	authorRepo := repository.NewAuthorRepository(database.DB)
+	articleViewRepo := repository.NewArticleViewRepository(database.DB)
+	reactionRepo := repository.NewReactionRepository(database.DB)
+	customFieldRepo := repository.NewCustomFieldRepository(database.DB)
+	pageRepo := repository.NewPageRepository(database.DB)
+	brokenLinkRepo := repository.NewBrokenLinkRepository(database.DB)
+	mediaRepo := repository.NewMediaRepository(database.DB)
+
+	// 后台任务
+	viewCounter := service.NewViewCounter(
+		articleRepo,
+		time.Duration(config.App.View.DedupWindowMinutes)*time.Minute,
+		time.Duration(config.App.View.FlushIntervalSeconds)*time.Second,
+	)
+	linkChecker := service.NewLinkChecker(articleRepo, pageRepo, brokenLinkRepo, service.LinkCheckOptions{
+		Interval:    time.Duration(config.App.LinkCheck.IntervalHours) * time.Hour,
+		Timeout:     time.Duration(config.App.LinkCheck.TimeoutSeconds) * time.Second,
+		Concurrency: config.App.LinkCheck.Concurrency,
+		UserAgent:   config.App.LinkCheck.UserAgent,
+		SiteURL:     config.App.LinkCheck.SiteURL,
+	})
+	cleanup := func() {
+		linkChecker.Stop()
+		viewCounter.Stop()
+	}
 
 	// Service 层
-	articleService := service.NewArticleService(articleRepo, tagRepo, categoryRepo)
+	tagCloud := service.NewTagCloud(tagRepo)
+	articleService := service.NewArticleService(articleRepo, tagRepo, categoryRepo, authorRepo, reactionRepo, customFieldRepo, viewCounter, tagCloud)
 	categoryService := service.NewCategoryService(categoryRepo)
-	tagService := service.NewTagService(tagRepo)
+	tagService := service.NewTagService(tagRepo, tagCloud)
 	commentService := service.NewCommentService(commentRepo, articleRepo)
+	authorService := service.NewAuthorService(authorRepo)
+	statsService := service.NewStatsService(articleViewRepo, articleRepo)
+	reactionService := service.NewReactionService(reactionRepo, articleRepo, config.App.Reaction.Types)
+	customFieldService := service.NewCustomFieldService(customFieldRepo)
+	pageService := service.NewPageService(pageRepo, articleRepo, tagCloud)
+	mediaService := service.NewMediaService(mediaRepo)
 
 	// Handler 层
 	articleHandler := handler.NewArticleHandler(articleService)
 	categoryHandler := handler.NewCategoryHandler(categoryService)
 	tagHandler := handler.NewTagHandler(tagService)
 	commentHandler := handler.NewCommentHandler(commentService)
-	uploadHandler := handler.NewUploadHandler()
-	statsHandler := handler.NewStatsHandler()
-	authHandler := handler.NewAuthHandler()
+	authorHandler := handler.NewAuthorHandler(authorService)
+	uploadHandler := handler.NewUploadHandler(mediaService, newResumableStore())
+	mediaHandler := handler.NewMediaHandler(mediaService)
+	statsHandler := handler.NewStatsHandler(statsService, reactionService, mediaService)
+	reactionHandler := handler.NewReactionHandler(reactionService)
+	customFieldHandler := handler.NewCustomFieldHandler(customFieldService)
+	pageHandler := handler.NewPageHandler(pageService, articleHandler)
+	linkCheckHandler := handler.NewLinkCheckHandler(linkChecker)
+	authHandler := handler.NewAuthHandler(authorService)
 
 	// ========== 静态文件服务 ==========
-	r.Static("/uploads", "./uploads") // 提供上传文件访问
+	// 仅本地存储需要由后端提供上传文件访问，S3 存储的文件地址直接指向存储服务
+	if local, ok := upload.DefaultConfig.Storage.(*storage.Local); ok {
+		uploads := r.Group(localUploadURL, middleware.HideDotFiles(), middleware.UploadHeaders())
+		uploads.Static("/", local.Root())
+	}
+	// 附件下载（设置 Content-Disposition 并统计下载次数，本地和 S3 存储均通过后端下载）
+	r.GET(upload.DownloadPrefix+"*key", mediaHandler.Download)
 
 	// ========== 公开 API ==========
 	api := r.Group("/api")
@@ -44,17 +94,42 @@ func SetupRouter() *gin.Engine {
 		// 文章相关
 		api.GET("/articles", articleHandler.List)
 		api.GET("/articles/:id", articleHandler.GetByID)
+		api.GET("/articles/slug/:slug", articleHandler.GetBySlug) // 按别名和语言获取
+		api.POST("/articles/:id/unlock", articleHandler.Unlock)   // 解锁加密文章
 
 		// 分类相关
 		api.GET("/categories", categoryHandler.List)
 		api.GET("/categories/stats", categoryHandler.ListWithCount)
+		api.GET("/categories/tree", categoryHandler.Tree)            // 分类树
+		api.GET("/categories/slug/:slug", categoryHandler.GetBySlug) // 按别名获取
 		api.GET("/categories/:id", categoryHandler.GetByID)
 
 		// 标签相关
 		api.GET("/tags", tagHandler.List)
 		api.GET("/tags/stats", tagHandler.ListWithCount)
+		api.GET("/tags/lookup", tagHandler.Lookup)        // 按名称或别名查找标签
+		api.GET("/tags/suggest", tagHandler.Suggest)      // 标签自动补全
+		api.GET("/tags/slug/:slug", tagHandler.GetBySlug) // 按别名获取
+		api.GET("/tags/cloud", tagHandler.Cloud)          // 标签云
 		api.GET("/tags/:id", tagHandler.GetByID)
 
+		// 作者相关
+		api.GET("/authors", authorHandler.List)
+		api.GET("/authors/:id", authorHandler.GetByID)
+		api.GET("/authors/:id/articles", articleHandler.ListByAuthor)
+
+		// 独立页面（不参与文章列表、搜索和统计）
+		api.GET("/pages", pageHandler.ListMenu) // 菜单页面
+		api.GET("/pages/:slug", pageHandler.GetBySlug)
+
+		// 自定义字段定义
+		api.GET("/custom-fields", customFieldHandler.List)
+
+		// 表态相关
+		api.GET("/reactions/types", reactionHandler.Types)
+		api.POST("/articles/:id/reactions", reactionHandler.Add)
+		api.DELETE("/articles/:id/reactions", reactionHandler.Remove)
+
 		// 评论相关（公开）
 		api.GET("/articles/:id/comments", commentHandler.ListByArticle) // 查看评论
 		api.POST("/comments", commentHandler.Create)                    // 提交评论
@@ -67,36 +142,92 @@ func SetupRouter() *gin.Engine {
 	}
 
 	// ========== 管理 API（Token认证）==========
+	// 作者账号可以管理自己的文章、上传文件和个人资料
 	admin := r.Group("/api/admin")
 	admin.Use(middleware.AdminAuth()) // 仅 Token 验证
 	{
 		// 文章管理
 		admin.POST("/articles", articleHandler.Create)
+		admin.POST("/articles/suggest-tags", articleHandler.SuggestTags) // 根据内容推荐标签
 		admin.PUT("/articles/:id", articleHandler.Update)
 		admin.DELETE("/articles/:id", articleHandler.Delete)
+		admin.DELETE("/articles/:id/password", articleHandler.RemovePassword)       // 取消加密
+		admin.POST("/articles/:id/convert-to-page", pageHandler.ConvertFromArticle) // 转为独立页面
+
+		// 作者资料（作者只能查看和修改自己）
+		admin.GET("/authors", authorHandler.ListAccounts) // 作者账号列表（含登录名）
+		admin.PUT("/authors/:id", authorHandler.Update)
+
+		// 文件上传
+		admin.POST("/upload/image", uploadHandler.UploadImage) // 上传图片
+		admin.POST("/upload/file", uploadHandler.UploadFile)   // 上传附件
+
+		// 断点续传（tus 协议）
+		admin.OPTIONS("/upload/resumable", uploadHandler.ResumableOptions)   // 查询服务端能力
+		admin.POST("/upload/resumable", uploadHandler.ResumableCreate)       // 创建上传任务
+		admin.HEAD("/upload/resumable/:id", uploadHandler.ResumableOffset)   // 查询已接收的偏移量
+		admin.PATCH("/upload/resumable/:id", uploadHandler.ResumableWrite)   // 上传分片
+		admin.DELETE("/upload/resumable/:id", uploadHandler.ResumableDelete) // 终止上传
+		admin.GET("/upload/resumable/:id", uploadHandler.ResumableStatus)    // 查询进度和结果
+	}
+
+	// ========== 站点管理 API（仅共享密钥登录的管理员）==========
+	manage := admin.Group("", middleware.AdminOnly())
+	{
+		// 页面管理
+		manage.GET("/pages", pageHandler.List)
+		manage.GET("/pages/:id", pageHandler.GetByID)
+		manage.POST("/pages", pageHandler.Create)
+		manage.PUT("/pages/:id", pageHandler.Update)
+		manage.DELETE("/pages/:id", pageHandler.Delete)
 
 		// 分类管理
-		admin.POST("/categories", categoryHandler.Create)
-		admin.PUT("/categories/:id", categoryHandler.Update)
-		admin.DELETE("/categories/:id", categoryHandler.Delete)
+		manage.POST("/categories", categoryHandler.Create)
+		manage.PUT("/categories/:id", categoryHandler.Update)
+		manage.DELETE("/categories/:id", categoryHandler.Delete)
+		manage.POST("/categories/:id/merge", categoryHandler.Merge) // 合并分类
 
 		// 标签管理
-		admin.POST("/tags", tagHandler.Create)
-		admin.PUT("/tags/:id", tagHandler.Update)
-		admin.DELETE("/tags/:id", tagHandler.Delete)
+		manage.POST("/tags", tagHandler.Create)
+		manage.PUT("/tags/:id", tagHandler.Update)
+		manage.DELETE("/tags/:id", tagHandler.Delete)
+		manage.POST("/tags/:id/merge", tagHandler.Merge)                     // 合并标签
+		manage.POST("/tags/:id/aliases", tagHandler.AddAlias)                // 添加别名
+		manage.DELETE("/tags/:id/aliases/:alias_id", tagHandler.DeleteAlias) // 删除别名
+
+		// 自定义字段管理
+		manage.POST("/custom-fields", customFieldHandler.Create)
+		manage.PUT("/custom-fields/:id", customFieldHandler.Update)
+		manage.DELETE("/custom-fields/:id", customFieldHandler.Delete)
+
+		// 作者管理
+		manage.POST("/authors", authorHandler.Create)
+		manage.DELETE("/authors/:id", authorHandler.Delete)
 
 		// 评论管理
-		admin.GET("/comments", commentHandler.ListAll)               // 所有评论
-		admin.PATCH("/comments/:id/approve", commentHandler.Approve) // 审核通过
-		admin.PATCH("/comments/:id/reject", commentHandler.Reject)   // 拒绝
-		admin.DELETE("/comments/:id", commentHandler.Delete)         // 删除
+		manage.GET("/comments", commentHandler.ListAll)               // 所有评论
+		manage.PATCH("/comments/:id/approve", commentHandler.Approve) // 审核通过
+		manage.PATCH("/comments/:id/reject", commentHandler.Reject)   // 拒绝
+		manage.DELETE("/comments/:id", commentHandler.Delete)         // 删除
 
-		// 文件上传
-		admin.POST("/upload/image", uploadHandler.UploadImage) // 上传图片
+		// 死链检查
+		manage.GET("/link-check", linkCheckHandler.Report)   // 死链报告
+		manage.POST("/link-check/run", linkCheckHandler.Run) // 立即检查
+
+		// 媒体库
+		manage.GET("/media", mediaHandler.List)
+		manage.GET("/media/:id", mediaHandler.GetByID)
+		manage.DELETE("/media/:id", mediaHandler.Delete)
+		manage.POST("/media/cleanup", mediaHandler.Cleanup) // 清理孤立文件
 
 		// 统计数据
-		admin.GET("/stats", statsHandler.GetDashboard) // 后台统计
+		manage.GET("/stats", statsHandler.GetDashboard)                            // 后台统计
+		manage.GET("/stats/views", statsHandler.SiteViewTrend)                     // 全站每日浏览量
+		manage.GET("/stats/articles/:id/views", statsHandler.ArticleViewTrend)     // 文章每日浏览量
+		manage.GET("/stats/top-articles", statsHandler.TopArticles)                // 热门文章排行
+		manage.GET("/stats/referrers", statsHandler.SiteReferrers)                 // 全站来源排行
+		manage.GET("/stats/articles/:id/referrers", statsHandler.ArticleReferrers) // 文章来源排行
 	}
 
-	return r
+	return r, cleanup
 }
@@ -0,0 +1,108 @@
+package router
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/zyy125/my-blog/backend/config"
+	"github.com/zyy125/my-blog/backend/internal/pkg/database"
+	"github.com/zyy125/my-blog/backend/internal/pkg/imaging"
+	"github.com/zyy125/my-blog/backend/internal/pkg/storage"
+	"github.com/zyy125/my-blog/backend/internal/pkg/upload"
+	"github.com/zyy125/my-blog/backend/internal/repository"
+	"github.com/zyy125/my-blog/backend/internal/service"
+)
+
+// localUploadURL 本地存储的文件访问路径前缀
+const localUploadURL = "/uploads/"
+
+// SetupUpload 根据配置初始化上传文件的存储后端和图片处理选项（需先初始化数据库，存储用量记录在数据库中）
+func SetupUpload() error {
+	// 1. 存储后端
+	cfg := config.App.Storage
+	switch cfg.Driver {
+	case "", "local":
+		root := cfg.LocalPath
+		if root == "" {
+			root = "./uploads"
+		}
+		upload.DefaultConfig.Storage = storage.NewLocal(root, localUploadURL)
+	case "s3":
+		s3, err := storage.NewS3(storage.S3Options{
+			Endpoint:  cfg.S3.Endpoint,
+			Region:    cfg.S3.Region,
+			Bucket:    cfg.S3.Bucket,
+			AccessKey: cfg.S3.AccessKey,
+			SecretKey: cfg.S3.SecretKey,
+			PathStyle: cfg.S3.PathStyle,
+			PublicURL: cfg.S3.PublicURL,
+		})
+		if err != nil {
+			return err
+		}
+		upload.DefaultConfig.Storage = s3
+	default:
+		return fmt.Errorf("不支持的存储类型: %s", cfg.Driver)
+	}
+	upload.DefaultConfig.Quota = cfg.QuotaMB * 1024 * 1024
+	upload.DefaultConfig.UsageStore = service.NewStorageUsageStore(repository.NewStorageUsageRepository(database.DB))
+
+	// 2. 图片处理选项
+	upload.DefaultConfig.Image = imaging.Options{
+		Widths:      config.App.Image.Widths,
+		ThumbWidth:  config.App.Image.ThumbWidth,
+		ThumbHeight: config.App.Image.ThumbHeight,
+		Quality:     config.App.Image.Quality,
+	}
+
+	// 3. 各类文件的上传策略（未配置的图片策略保持默认，其他类别不允许上传）
+	policies := map[string]config.UploadPolicyConfig{
+		upload.CategoryImage:    config.App.Upload.Image,
+		upload.CategoryDocument: config.App.Upload.Document,
+		upload.CategoryArchive:  config.App.Upload.Archive,
+		upload.CategoryAudio:    config.App.Upload.Audio,
+	}
+	for category, cfg := range policies {
+		if cfg.MaxSizeMB <= 0 {
+			continue
+		}
+		policy, err := newUploadPolicy(category, cfg)
+		if err != nil {
+			return err
+		}
+		upload.DefaultConfig.Policies[category] = policy
+	}
+
+	return nil
+}
+
+// newUploadPolicy 根据配置创建上传策略，并检查 MIME 类型是否属于该类别
+func newUploadPolicy(category string, cfg config.UploadPolicyConfig) (*upload.Policy, error) {
+	types := cfg.AllowedTypes
+	if len(types) == 0 {
+		types = upload.MIMETypes(category)
+	}
+	for _, mime := range types {
+		if t := upload.TypeByMIME(mime); t == nil || t.Category != category {
+			return nil, fmt.Errorf("上传策略 %s 不支持的文件类型: %s", category, mime)
+		}
+	}
+	return &upload.Policy{
+		MaxSize:      int64(cfg.MaxSizeMB) * 1024 * 1024,
+		AllowedTypes: types,
+	}, nil
+}
+
+// newResumableStore 根据配置创建分片上传的临时数据存储
+func newResumableStore() *upload.ResumableStore {
+	cfg := config.App.Upload
+	dir := cfg.PartialDir
+	if dir == "" {
+		dir = "./uploads/.partial"
+	}
+	expire := time.Duration(cfg.PartialExpireHours) * time.Hour
+	if expire <= 0 {
+		expire = 24 * time.Hour
+	}
+	return upload.NewResumableStore(dir, expire)
+}
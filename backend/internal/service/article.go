@@ -2,18 +2,40 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"regexp"
+	"strings"
 
 	"github.com/zyy125/my-blog/backend/internal/model"
 	"github.com/zyy125/my-blog/backend/internal/repository"
+	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
+// ArticleFilter 文章列表的通用筛选条件
+type ArticleFilter = repository.ArticleFilter
+
+// ErrArticleLocked 加密文章未解锁
+var ErrArticleLocked = errors.New("该文章已加密，请输入密码后查看")
+
+// defaultLanguage 文章默认语言
+const defaultLanguage = "zh"
+
+// slugPattern 文章别名格式
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
 // ArticleService 文章业务逻辑层
 type ArticleService struct {
-	repo    *repository.ArticleRepository
-	tagRepo *repository.TagRepository      // ✅ 新增：标签仓库
-	catRepo *repository.CategoryRepository // ✅ 新增：分类仓库
+	repo         *repository.ArticleRepository
+	tagRepo      *repository.TagRepository         // ✅ 新增：标签仓库
+	catRepo      *repository.CategoryRepository    // ✅ 新增：分类仓库
+	authorRepo   *repository.AuthorRepository      // 作者仓库
+	reactionRepo *repository.ReactionRepository    // 表态仓库
+	fieldRepo    *repository.CustomFieldRepository // 自定义字段仓库
+	views        *ViewCounter                      // 浏览量聚合器
+	tagCloud     *TagCloud                         // 标签云（文章变更时失效）
 }
 
 // NewArticleService 创建文章服务实例
@@ -21,11 +43,21 @@ func NewArticleService(
 	repo *repository.ArticleRepository,
 	tagRepo *repository.TagRepository,
 	catRepo *repository.CategoryRepository,
+	authorRepo *repository.AuthorRepository,
+	reactionRepo *repository.ReactionRepository,
+	fieldRepo *repository.CustomFieldRepository,
+	views *ViewCounter,
+	tagCloud *TagCloud,
 ) *ArticleService {
 	return &ArticleService{
-		repo:    repo,
-		tagRepo: tagRepo,
-		catRepo: catRepo,
+		repo:         repo,
+		tagRepo:      tagRepo,
+		catRepo:      catRepo,
+		authorRepo:   authorRepo,
+		reactionRepo: reactionRepo,
+		fieldRepo:    fieldRepo,
+		views:        views,
+		tagCloud:     tagCloud,
 	}
 }
 
@@ -41,18 +73,29 @@ func (s *ArticleService) Create(ctx context.Context, article *model.Article) err
 
 	// 2. 数据处理
 	// 如果没有提供摘要，自动从内容截取
-	if article.Summary == "" && len(article.Content) > 100 {
+	if article.Summary == "" && article.Password == "" && len(article.Content) > 100 { // 加密文章不从正文截取摘要
 		article.Summary = article.Content[:100] + "..."
 	}
+	if err := hashArticlePassword(article); err != nil {
+		return err
+	}
+	if err := s.prepareTranslation(ctx, article); err != nil {
+		return err
+	}
+	if err := s.prepareMetadata(ctx, article); err != nil {
+		return err
+	}
 
 	// 3. 调用 Repository 创建
 	return s.repo.Create(ctx, article)
 }
 
-// GetByID 获取文章详情（并增加浏览量）
-func (s *ArticleService) GetByID(ctx context.Context, id uint) (*model.Article, error) {
-	// 1. 查询文章
-	article, err := s.repo.GetByID(ctx, id)
+// GetByID 获取文章详情
+// unlocked 表示调用方已解锁该文章（持有有效的解锁 Token 或管理员 Token）；
+// 加密文章未解锁时返回隐藏正文的文章和 ErrArticleLocked
+func (s *ArticleService) GetByID(ctx context.Context, id uint, unlocked bool) (*model.Article, error) {
+	// 1. 查询文章（包含分类、作者和标签）
+	article, err := s.repo.GetByIDWithAssociations(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("文章不存在")
@@ -60,16 +103,61 @@ func (s *ArticleService) GetByID(ctx context.Context, id uint) (*model.Article,
 		return nil, err
 	}
 
-	// 2. 增加浏览量（异步，不影响返回）
-	go func() {
-		_ = s.repo.IncrementViews(context.Background(), id)
-	}()
+	// 2. 加密文章需要先解锁
+	if article.IsProtected && !unlocked {
+		article.Content = ""
+		article.Metadata = nil
+		return article, ErrArticleLocked
+	}
+
+	// 3. 附加分类路径、其他语言版本和表态统计
+	if article.CategoryID != nil {
+		categories, err := s.catRepo.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		article.Breadcrumbs = categoryBreadcrumbs(categories, *article.CategoryID)
+	}
+	article.Translations, err = s.repo.ListTranslations(ctx, article.TranslationGroup(), article.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachReactions(ctx, []*model.Article{article}); err != nil {
+		return nil, err
+	}
 
 	return article, nil
 }
 
+// ResolveSlug 根据别名和语言偏好选择文章版本，返回文章 ID
+// preferred 为按优先级排序的语言列表；没有匹配的语言时返回原文
+func (s *ArticleService) ResolveSlug(ctx context.Context, slug string, preferred []string) (uint, error) {
+	articles, err := s.repo.ListBySlug(ctx, strings.ToLower(strings.TrimSpace(slug)))
+	if err != nil {
+		return 0, err
+	}
+
+	// 只考虑已发布的版本
+	var published []*model.Article
+	for _, article := range articles {
+		if article.Status == 1 {
+			published = append(published, article)
+		}
+	}
+	if len(published) == 0 {
+		return 0, errors.New("文章不存在")
+	}
+
+	return pickTranslation(published, preferred).ID, nil
+}
+
+// RecordView 记录一次文章浏览（去重后由聚合器批量写入）
+func (s *ArticleService) RecordView(id uint, viewer ViewInfo) {
+	s.views.Record(id, viewer)
+}
+
 // List 获取文章列表
-func (s *ArticleService) List(ctx context.Context, page, pageSize int, status *int8) ([]*model.Article, int64, error) {
+func (s *ArticleService) List(ctx context.Context, page, pageSize int, status *int8, filter ArticleFilter) ([]*model.Article, int64, error) {
 	// 参数验证
 	if page < 1 {
 		page = 1
@@ -78,7 +166,19 @@ func (s *ArticleService) List(ctx context.Context, page, pageSize int, status *i
 		pageSize = 10
 	}
 
-	return s.repo.ListWithAssociations(ctx, page, pageSize, status)
+	filter, err := s.normalizeFilter(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	articles, total, err := s.repo.ListWithAssociations(ctx, page, pageSize, status, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	hideProtectedContent(articles)
+	if err := s.attachReactions(ctx, articles); err != nil {
+		return nil, 0, err
+	}
+	return articles, total, nil
 }
 
 // Update 更新文章
@@ -97,12 +197,27 @@ func (s *ArticleService) Update(ctx context.Context, article *model.Article) err
 		return errors.New("标题不能为空")
 	}
 
-	// 3. 保留某些字段（如创建时间、浏览量）
+	// 3. 保留某些字段（如创建时间、浏览量、访问密码）
 	article.CreatedAt = existing.CreatedAt
 	article.Views = existing.Views
+	article.AuthorID = existing.AuthorID
+	if err := keepOrHashArticlePassword(article, existing); err != nil {
+		return err
+	}
+
+	if err := s.keepOrPrepareTranslation(ctx, article, existing); err != nil {
+		return err
+	}
+	if err := s.prepareMetadata(ctx, article); err != nil {
+		return err
+	}
 
-	// 4. 执行更新
-	return s.repo.Update(ctx, article)
+	// 4. 执行更新（发布状态可能变化，标签云需重新统计）
+	if err := s.repo.Update(ctx, article); err != nil {
+		return err
+	}
+	s.tagCloud.Invalidate()
+	return nil
 }
 
 // Delete 删除文章
@@ -117,11 +232,16 @@ func (s *ArticleService) Delete(ctx context.Context, id uint) error {
 	}
 
 	// 2. 执行删除
-	return s.repo.Delete(ctx, id)
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.tagCloud.Invalidate()
+	return nil
 }
 
 // CreateWithTags 创建文章（带标签关联）
-func (s *ArticleService) CreateWithTags(ctx context.Context, article *model.Article, tagIDs []uint) error {
+// tagNames 中不存在的标签会在同一事务中自动创建
+func (s *ArticleService) CreateWithTags(ctx context.Context, article *model.Article, tagIDs []uint, tagNames []string) error {
 	// 1. 业务验证
 	if article.Title == "" {
 		return errors.New("标题不能为空")
@@ -142,38 +262,38 @@ func (s *ArticleService) CreateWithTags(ctx context.Context, article *model.Arti
 	}
 
 	// 3. 自动生成摘要
-	if article.Summary == "" && len(article.Content) > 100 {
+	if article.Summary == "" && article.Password == "" && len(article.Content) > 100 { // 加密文章不从正文截取摘要
 		article.Summary = article.Content[:100] + "..."
 	}
-
-	// 4. 创建文章
-	if err := s.repo.Create(ctx, article); err != nil {
+	if err := hashArticlePassword(article); err != nil {
 		return err
 	}
 
-	// 5. 关联标签
-	if len(tagIDs) > 0 {
-		// 验证标签是否都存在
-		tags, err := s.tagRepo.GetByIDs(ctx, tagIDs)
-		if err != nil {
-			return err
-		}
-		if len(tags) != len(tagIDs) {
-			return errors.New("部分标签不存在")
-		}
+	// 4. 校验语言、翻译关联和自定义字段
+	if err := s.prepareTranslation(ctx, article); err != nil {
+		return err
+	}
+	if err := s.prepareMetadata(ctx, article); err != nil {
+		return err
+	}
 
-		// 关联标签到文章
-		article.Tags = tags
-		if err := s.repo.Update(ctx, article); err != nil {
-			return err
-		}
+	// 5. 校验标签
+	tagNames, err := s.prepareTags(ctx, tagIDs, tagNames)
+	if err != nil {
+		return err
 	}
 
+	// 6. 使用事务创建文章和标签
+	if err := s.repo.CreateWithTags(ctx, article, tagIDs, tagNames); err != nil {
+		return err
+	}
+	s.tagCloud.Invalidate()
 	return nil
 }
 
 // UpdateWithTags 更新文章（带标签关联）
-func (s *ArticleService) UpdateWithTags(ctx context.Context, article *model.Article, tagIDs []uint) error {
+// tagNames 中不存在的标签会在同一事务中自动创建
+func (s *ArticleService) UpdateWithTags(ctx context.Context, article *model.Article, tagIDs []uint, tagNames []string) error {
 	// 1. 检查文章是否存在
 	existing, err := s.repo.GetByID(ctx, article.ID)
 	if err != nil {
@@ -205,13 +325,49 @@ func (s *ArticleService) UpdateWithTags(ctx context.Context, article *model.Arti
 	// 4. 保留某些字段
 	article.CreatedAt = existing.CreatedAt
 	article.Views = existing.Views
+	article.AuthorID = existing.AuthorID
+	if err := keepOrHashArticlePassword(article, existing); err != nil {
+		return err
+	}
+
+	// 5. 校验语言、翻译关联和自定义字段
+	if err := s.keepOrPrepareTranslation(ctx, article, existing); err != nil {
+		return err
+	}
+	if err := s.prepareMetadata(ctx, article); err != nil {
+		return err
+	}
+
+	// 6. 校验标签
+	tagNames, err = s.prepareTags(ctx, tagIDs, tagNames)
+	if err != nil {
+		return err
+	}
 
-	// 5. 使用事务更新文章和标签
-	return s.repo.UpdateWithTags(ctx, article, tagIDs)
+	// 7. 使用事务更新文章和标签
+	if err := s.repo.UpdateWithTags(ctx, article, tagIDs, tagNames); err != nil {
+		return err
+	}
+	s.tagCloud.Invalidate()
+	return nil
+}
+
+// prepareTags 检查标签 ID 是否都存在，并规范化标签名称
+func (s *ArticleService) prepareTags(ctx context.Context, tagIDs []uint, tagNames []string) ([]string, error) {
+	if len(tagIDs) > 0 {
+		tags, err := s.tagRepo.GetByIDs(ctx, tagIDs)
+		if err != nil {
+			return nil, err
+		}
+		if len(tags) != len(tagIDs) {
+			return nil, errors.New("部分标签不存在")
+		}
+	}
+	return normalizeTagNames(tagNames)
 }
 
-// ListByCategory 根据分类查询文章
-func (s *ArticleService) ListByCategory(ctx context.Context, categoryID uint, page, pageSize int) ([]*model.Article, int64, error) {
+// ListByCategory 根据分类查询文章，includeChildren 为 true 时包含子孙分类下的文章
+func (s *ArticleService) ListByCategory(ctx context.Context, categoryID uint, includeChildren bool, page, pageSize int, filter ArticleFilter) ([]*model.Article, int64, error) {
 	// 参数验证
 	if page < 1 {
 		page = 1
@@ -229,11 +385,82 @@ func (s *ArticleService) ListByCategory(ctx context.Context, categoryID uint, pa
 		return nil, 0, err
 	}
 
-	return s.repo.ListByCategory(ctx, categoryID, page, pageSize)
+	categoryIDs := []uint{categoryID}
+	if includeChildren {
+		categories, err := s.catRepo.List(ctx)
+		if err != nil {
+			return nil, 0, err
+		}
+		categoryIDs = categoryDescendants(categories, categoryID)
+	}
+
+	filter, err = s.normalizeFilter(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	articles, total, err := s.repo.ListByCategory(ctx, categoryIDs, page, pageSize, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	hideProtectedContent(articles)
+	if err := s.attachReactions(ctx, articles); err != nil {
+		return nil, 0, err
+	}
+	return articles, total, nil
+}
+
+// ListByAuthor 根据作者查询文章
+func (s *ArticleService) ListByAuthor(ctx context.Context, authorID uint, page, pageSize int, filter ArticleFilter) ([]*model.Article, int64, error) {
+	// 参数验证
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	// 验证作者是否存在
+	_, err := s.authorRepo.GetByID(ctx, authorID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, 0, errors.New("作者不存在")
+		}
+		return nil, 0, err
+	}
+
+	filter, err = s.normalizeFilter(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	articles, total, err := s.repo.ListByAuthor(ctx, authorID, page, pageSize, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	hideProtectedContent(articles)
+	if err := s.attachReactions(ctx, articles); err != nil {
+		return nil, 0, err
+	}
+	return articles, total, nil
+}
+
+// CheckOwner 检查文章是否属于指定作者
+func (s *ArticleService) CheckOwner(ctx context.Context, id, authorID uint) error {
+	article, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("文章不存在")
+		}
+		return err
+	}
+
+	if article.AuthorID == nil || *article.AuthorID != authorID {
+		return errors.New("无权操作其他作者的文章")
+	}
+	return nil
 }
 
 // ListByTag 根据标签查询文章
-func (s *ArticleService) ListByTag(ctx context.Context, tagID uint, page, pageSize int) ([]*model.Article, int64, error) {
+func (s *ArticleService) ListByTag(ctx context.Context, tagID uint, page, pageSize int, filter ArticleFilter) ([]*model.Article, int64, error) {
 	// 参数验证
 	if page < 1 {
 		page = 1
@@ -251,11 +478,23 @@ func (s *ArticleService) ListByTag(ctx context.Context, tagID uint, page, pageSi
 		return nil, 0, err
 	}
 
-	return s.repo.ListByTag(ctx, tagID, page, pageSize)
+	filter, err = s.normalizeFilter(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	articles, total, err := s.repo.ListByTag(ctx, tagID, page, pageSize, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	hideProtectedContent(articles)
+	if err := s.attachReactions(ctx, articles); err != nil {
+		return nil, 0, err
+	}
+	return articles, total, nil
 }
 
 // Search 搜索文章（标题或内容）
-func (s *ArticleService) Search(ctx context.Context, keyword string, page, pageSize int) ([]*model.Article, int64, error) {
+func (s *ArticleService) Search(ctx context.Context, keyword string, page, pageSize int, filter ArticleFilter) ([]*model.Article, int64, error) {
 	if keyword == "" {
 		return nil, 0, errors.New("搜索关键词不能为空")
 	}
@@ -267,5 +506,237 @@ func (s *ArticleService) Search(ctx context.Context, keyword string, page, pageS
 		pageSize = 10
 	}
 
-	return s.repo.Search(ctx, keyword, page, pageSize)
+	filter, err := s.normalizeFilter(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	articles, total, err := s.repo.Search(ctx, keyword, page, pageSize, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	hideProtectedContent(articles)
+	if err := s.attachReactions(ctx, articles); err != nil {
+		return nil, 0, err
+	}
+	return articles, total, nil
+}
+
+// VerifyPassword 校验加密文章的访问密码，成功时返回当前密码版本（用于使已签发的解锁 Token 在改密后失效）
+func (s *ArticleService) VerifyPassword(ctx context.Context, id uint, password string) (string, error) {
+	article, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", errors.New("文章不存在")
+		}
+		return "", err
+	}
+
+	if !article.IsProtected {
+		return "", errors.New("该文章未加密")
+	}
+	if bcrypt.CompareHashAndPassword([]byte(article.Password), []byte(password)) != nil {
+		return "", errors.New("密码错误")
+	}
+	return passwordVersion(article.Password), nil
+}
+
+// PasswordVersion 返回文章当前的密码版本（未加密时为空）
+func (s *ArticleService) PasswordVersion(ctx context.Context, id uint) (string, error) {
+	article, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if !article.IsProtected {
+		return "", nil
+	}
+	return passwordVersion(article.Password), nil
+}
+
+// passwordVersion 由密码哈希派生版本号（bcrypt 每次加盐不同，修改密码后版本必然变化）
+func passwordVersion(hash string) string {
+	sum := sha256.Sum256([]byte(hash))
+	return hex.EncodeToString(sum[:8])
+}
+
+// RemovePassword 取消文章加密
+func (s *ArticleService) RemovePassword(ctx context.Context, id uint) error {
+	_, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("文章不存在")
+		}
+		return err
+	}
+
+	return s.repo.UpdatePassword(ctx, id, "")
+}
+
+// keepOrPrepareTranslation 更新时未指定翻译关联则保留原关联，再校验语言和别名
+func (s *ArticleService) keepOrPrepareTranslation(ctx context.Context, article, existing *model.Article) error {
+	if article.TranslationGroupID == nil {
+		article.TranslationGroupID = existing.TranslationGroupID
+	}
+	return s.prepareTranslation(ctx, article)
+}
+
+// prepareTranslation 归一化语言和别名，并校验翻译关联
+// article.TranslationGroupID 可以是同组内任意文章的 ID，这里会解析为组 ID
+func (s *ArticleService) prepareTranslation(ctx context.Context, article *model.Article) error {
+	// 1. 归一化语言和别名
+	article.Language = normalizeLanguage(article.Language)
+	if article.Language == "" {
+		article.Language = defaultLanguage
+	}
+	article.Slug = strings.ToLower(strings.TrimSpace(article.Slug))
+	if article.Slug != "" && !slugPattern.MatchString(article.Slug) {
+		return errors.New("别名只能包含小写字母、数字和连字符")
+	}
+
+	// 2. 解析翻译组，同组内每种语言只能有一个版本
+	if article.TranslationGroupID != nil {
+		source, err := s.repo.GetByID(ctx, *article.TranslationGroupID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("原文不存在")
+			}
+			return err
+		}
+		groupID := source.TranslationGroup()
+		if groupID == article.ID {
+			// 自己就是原文
+			article.TranslationGroupID = nil
+		} else {
+			article.TranslationGroupID = &groupID
+		}
+
+		members, err := s.repo.ListByTranslationGroup(ctx, groupID)
+		if err != nil {
+			return err
+		}
+		for _, member := range members {
+			if member.ID != article.ID && member.Language == article.Language {
+				return errors.New("该语言版本已存在")
+			}
+		}
+
+		// 未指定别名时沿用原文别名
+		if article.Slug == "" {
+			article.Slug = source.Slug
+		}
+	}
+
+	// 3. 别名只能由同一翻译组的文章共用
+	if article.Slug != "" {
+		others, err := s.repo.ListBySlug(ctx, article.Slug)
+		if err != nil {
+			return err
+		}
+		for _, other := range others {
+			if other.ID == article.ID {
+				continue
+			}
+			if other.TranslationGroup() != article.TranslationGroup() {
+				return errors.New("别名已被其他文章使用")
+			}
+			if other.Language == article.Language {
+				return errors.New("该语言版本已存在")
+			}
+		}
+	}
+
+	return nil
+}
+
+// prepareMetadata 按自定义字段定义校验并规范化文章元数据
+func (s *ArticleService) prepareMetadata(ctx context.Context, article *model.Article) error {
+	fields, err := s.fieldRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	metadata, err := validateMetadata(fields, article.Metadata)
+	if err != nil {
+		return err
+	}
+	article.Metadata = metadata
+	return nil
+}
+
+// normalizeFilter 归一化筛选条件，自定义字段筛选只允许已定义的字段
+func (s *ArticleService) normalizeFilter(ctx context.Context, filter ArticleFilter) (ArticleFilter, error) {
+	filter.Language = normalizeLanguage(filter.Language)
+	if len(filter.Metadata) == 0 {
+		return filter, nil
+	}
+
+	fields, err := s.fieldRepo.List(ctx)
+	if err != nil {
+		return filter, err
+	}
+	defined := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		defined[field.Name] = true
+	}
+	for key := range filter.Metadata {
+		if !defined[key] {
+			return filter, errors.New("未定义的自定义字段: " + key)
+		}
+	}
+	return filter, nil
+}
+
+// attachReactions 为文章附加表态统计
+func (s *ArticleService) attachReactions(ctx context.Context, articles []*model.Article) error {
+	if len(articles) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, 0, len(articles))
+	for _, article := range articles {
+		ids = append(ids, article.ID)
+	}
+	counts, err := countReactions(ctx, s.reactionRepo, ids)
+	if err != nil {
+		return err
+	}
+	for _, article := range articles {
+		article.Reactions = counts[article.ID]
+	}
+	return nil
+}
+
+// hashArticlePassword 将文章上的明文密码替换为 bcrypt 哈希
+func hashArticlePassword(article *model.Article) error {
+	if article.Password == "" {
+		article.IsProtected = false
+		return nil
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(article.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return errors.New("密码加密失败")
+	}
+	article.Password = string(hash)
+	article.IsProtected = true
+	return nil
+}
+
+// keepOrHashArticlePassword 更新时未提供新密码则保留原密码，否则重新哈希
+func keepOrHashArticlePassword(article, existing *model.Article) error {
+	if article.Password == "" {
+		article.Password = existing.Password
+		article.IsProtected = existing.IsProtected
+		return nil
+	}
+	return hashArticlePassword(article)
+}
+
+// hideProtectedContent 列表中隐藏加密文章的正文和自定义字段，只保留标题和摘要
+func hideProtectedContent(articles []*model.Article) {
+	for _, article := range articles {
+		if article.IsProtected {
+			article.Content = ""
+			article.Metadata = nil
+		}
+	}
 }
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/zyy125/my-blog/backend/internal/model"
+	"github.com/zyy125/my-blog/backend/internal/pkg/segment"
+)
+
+const (
+	defaultTagSuggestLimit = 10 // 每组默认返回的推荐数量
+	titleTermWeight        = 2  // 标题中的词按出现两次计算
+	minCandidateCount      = 2  // 新标签至少在文中出现的次数
+)
+
+// TagSuggestion 标签推荐结果
+type TagSuggestion struct {
+	TagID    *uint   `json:"tag_id,omitempty"` // 已有标签的 ID（新标签为空）
+	Name     string  `json:"name"`
+	Existing bool    `json:"existing"` // 是否为已有标签
+	Count    int     `json:"count"`    // 在文中出现的次数（标题加权）
+	Score    float64 `json:"score"`    // TF-IDF 得分
+}
+
+// SuggestTags 根据文章内容推荐标签：已有标签在前，新标签在后，各自按 TF-IDF 得分排序，每组最多 limit 个
+// 以现有文章为语料计算 IDF；excludeID 为正在编辑的文章，不计入语料
+func (s *ArticleService) SuggestTags(ctx context.Context, title, content string, excludeID uint, limit int) ([]TagSuggestion, error) {
+	if limit < 1 || limit > 50 {
+		limit = defaultTagSuggestLimit
+	}
+
+	// 1. 以已有标签及其别名为词典
+	tags, err := s.tagRepo.ListWithAliases(ctx)
+	if err != nil {
+		return nil, err
+	}
+	seg := segment.New()
+	tagByKey := make(map[string]*model.Tag)
+	for _, tag := range tags {
+		names := []string{tag.Name}
+		for _, alias := range tag.Aliases {
+			names = append(names, alias.Alias)
+		}
+		for _, name := range names {
+			seg.AddWords(name)
+			tagByKey[segment.Key(name)] = tag
+		}
+	}
+
+	// 2. 发现文中重复出现的新词并分词统计词频
+	title, content = segment.PlainText(title), segment.PlainText(content)
+	seg.AddWords(seg.Discover(title+"\n"+content, minCandidateCount)...)
+	counts := make(map[string]int)
+	for _, term := range seg.Cut(title) {
+		counts[term] += titleTermWeight
+	}
+	for _, term := range seg.Cut(content) {
+		counts[term]++
+	}
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+	if total == 0 {
+		return []TagSuggestion{}, nil
+	}
+
+	// 3. 统计文档频率
+	corpus, err := s.repo.ListTexts(ctx, excludeID)
+	if err != nil {
+		return nil, err
+	}
+	docFreq := make(map[string]int)
+	for _, article := range corpus {
+		seen := make(map[string]bool)
+		for _, term := range seg.Cut(segment.PlainText(article.Title + "\n" + article.Content)) {
+			if counts[term] > 0 && !seen[term] {
+				seen[term] = true
+				docFreq[term]++
+			}
+		}
+	}
+
+	// 4. 计算 TF-IDF，同一标签的名称和别名合并计分
+	var existing, candidates []TagSuggestion
+	existingIndex := make(map[uint]int)
+	for term, count := range counts {
+		idf := math.Log(float64(len(corpus)+1)/float64(docFreq[term]+1)) + 1
+		score := float64(count) / float64(total) * idf
+
+		if tag, ok := tagByKey[term]; ok {
+			if i, ok := existingIndex[tag.ID]; ok {
+				existing[i].Count += count
+				existing[i].Score += score
+				continue
+			}
+			id := tag.ID
+			existingIndex[id] = len(existing)
+			existing = append(existing, TagSuggestion{TagID: &id, Name: tag.Name, Existing: true, Count: count, Score: score})
+			continue
+		}
+		if count >= minCandidateCount {
+			candidates = append(candidates, TagSuggestion{Name: term, Count: count, Score: score})
+		}
+	}
+
+	// 5. 排序并截取
+	return append(rankTagSuggestions(existing, limit), rankTagSuggestions(candidates, limit)...), nil
+}
+
+// rankTagSuggestions 按得分从高到低排序并截取前 limit 个
+func rankTagSuggestions(list []TagSuggestion, limit int) []TagSuggestion {
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Score != list[j].Score {
+			return list[i].Score > list[j].Score
+		}
+		return list[i].Name < list[j].Name
+	})
+	if len(list) > limit {
+		list = list[:limit]
+	}
+	for i := range list {
+		list[i].Score = math.Round(list[i].Score*10000) / 10000
+	}
+	return list
+}
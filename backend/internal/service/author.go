@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/zyy125/my-blog/backend/internal/model"
+	"github.com/zyy125/my-blog/backend/internal/repository"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// AuthorService 作者业务逻辑层
+type AuthorService struct {
+	repo *repository.AuthorRepository
+}
+
+// NewAuthorService 创建作者服务实例
+func NewAuthorService(repo *repository.AuthorRepository) *AuthorService {
+	return &AuthorService{repo: repo}
+}
+
+// Create 创建作者（author.Password 传入明文，保存前哈希）
+func (s *AuthorService) Create(ctx context.Context, author *model.Author) error {
+	// 1. 验证
+	if author.Username == "" {
+		return errors.New("登录名不能为空")
+	}
+	if author.Name == "" {
+		return errors.New("作者名称不能为空")
+	}
+	if author.Password == "" {
+		return errors.New("登录密钥不能为空")
+	}
+
+	// 2. 检查登录名是否已存在
+	existing, err := s.repo.GetByUsername(ctx, author.Username)
+	if err == nil && existing != nil {
+		return errors.New("登录名已存在")
+	}
+
+	// 3. 哈希密钥
+	hash, err := bcrypt.GenerateFromPassword([]byte(author.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return errors.New("密钥加密失败")
+	}
+	author.Password = string(hash)
+
+	// 4. 创建
+	return s.repo.Create(ctx, author)
+}
+
+// GetByID 获取作者详情
+func (s *AuthorService) GetByID(ctx context.Context, id uint) (*model.Author, error) {
+	author, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("作者不存在")
+		}
+		return nil, err
+	}
+	return author, nil
+}
+
+// List 获取所有作者
+func (s *AuthorService) List(ctx context.Context) ([]*model.Author, error) {
+	return s.repo.List(ctx)
+}
+
+// Update 更新作者（author.Password 为空表示不修改密钥）
+func (s *AuthorService) Update(ctx context.Context, author *model.Author) error {
+	// 1. 检查是否存在
+	existing, err := s.repo.GetByID(ctx, author.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("作者不存在")
+		}
+		return err
+	}
+
+	// 2. 验证
+	if author.Username == "" {
+		return errors.New("登录名不能为空")
+	}
+	if author.Name == "" {
+		return errors.New("作者名称不能为空")
+	}
+
+	// 3. 检查登录名是否与其他作者重复
+	other, err := s.repo.GetByUsername(ctx, author.Username)
+	if err == nil && other != nil && other.ID != author.ID {
+		return errors.New("登录名已存在")
+	}
+
+	// 4. 处理密钥
+	if author.Password == "" {
+		author.Password = existing.Password
+	} else {
+		hash, err := bcrypt.GenerateFromPassword([]byte(author.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return errors.New("密钥加密失败")
+		}
+		author.Password = string(hash)
+	}
+	author.CreatedAt = existing.CreatedAt
+
+	// 5. 更新
+	return s.repo.Update(ctx, author)
+}
+
+// Delete 删除作者
+func (s *AuthorService) Delete(ctx context.Context, id uint) error {
+	// 1. 检查是否存在
+	_, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("作者不存在")
+		}
+		return err
+	}
+
+	// 2. 检查是否有文章属于该作者
+	count, err := s.repo.CountArticles(ctx, id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("该作者下有文章，无法删除")
+	}
+
+	// 3. 删除
+	return s.repo.Delete(ctx, id)
+}
+
+// Authenticate 校验作者登录名和密钥
+func (s *AuthorService) Authenticate(ctx context.Context, username, password string) (*model.Author, error) {
+	author, err := s.repo.GetByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("登录名或密钥错误")
+		}
+		return nil, err
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(author.Password), []byte(password)) != nil {
+		return nil, errors.New("登录名或密钥错误")
+	}
+	return author, nil
+}
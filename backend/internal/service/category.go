@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"strings"
 	"github.com/zyy125/my-blog/backend/internal/model"
 	"github.com/zyy125/my-blog/backend/internal/repository"
 	"gorm.io/gorm"
@@ -31,8 +32,22 @@ func (s *CategoryService) Create(ctx context.Context, category *model.Category)
 		return errors.New("分类名称已存在")
 	}
 	
-	// 3. 创建
-	return s.repo.Create(ctx, category)
+	// 3. 检查父分类和 URL 别名
+	if err := s.validateParent(ctx, category); err != nil {
+		return err
+	}
+	if err := s.prepareSlug(ctx, category); err != nil {
+		return err
+	}
+	
+	// 4. 创建（并发请求使用相同名称或别名时由唯一索引拒绝）
+	if err := s.repo.Create(ctx, category); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errors.New("分类名称或 URL 别名已存在")
+		}
+		return err
+	}
+	return nil
 }
 
 // GetByID 获取分类详情
@@ -47,13 +62,25 @@ func (s *CategoryService) GetByID(ctx context. Context, id uint) (*model.Categor
 	return category, nil
 }
 
+// GetBySlug 根据 URL 别名获取分类详情
+func (s *CategoryService) GetBySlug(ctx context.Context, slug string) (*model.Category, error) {
+	category, err := s.repo.GetBySlug(ctx, strings.ToLower(strings.TrimSpace(slug)))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("分类不存在")
+		}
+		return nil, err
+	}
+	return category, nil
+}
+
 // List 获取所有分类
 func (s *CategoryService) List(ctx context.Context) ([]*model.Category, error) {
 	return s.repo.List(ctx)
 }
 
-// Update 更新分类
-func (s *CategoryService) Update(ctx context.Context, category *model.Category) error {
+// Update 更新分类，columns 为需要写入的列（category 需包含其余字段的当前值，用于校验）
+func (s *CategoryService) Update(ctx context.Context, category *model.Category, columns []string) error {
 	// 1. 检查是否存在
 	_, err := s.repo.GetByID(ctx, category.ID)
 	if err != nil {
@@ -74,12 +101,30 @@ func (s *CategoryService) Update(ctx context.Context, category *model.Category)
 		return errors.New("分类名称已存在")
 	}
 	
-	// 4. 更新
-	return s.repo.Update(ctx, category)
+	// 4. 检查父分类（不能移动到自身或子孙分类下）和 URL 别名
+	if err := s.validateParent(ctx, category); err != nil {
+		return err
+	}
+	if err := s.prepareSlug(ctx, category); err != nil {
+		return err
+	}
+	
+	// 5. 只更新指定的列
+	if len(columns) == 0 {
+		return nil
+	}
+	if err := s.repo.Update(ctx, category, columns); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errors.New("分类名称或 URL 别名已存在")
+		}
+		return err
+	}
+	return nil
 }
 
 // Delete 删除分类
-func (s *CategoryService) Delete(ctx context.Context, id uint) error {
+// reassign 为 true 时在同一事务中将文章转移到 targetID 指定的分类（为 nil 时变为未分类），否则分类下有文章时拒绝删除
+func (s *CategoryService) Delete(ctx context.Context, id uint, reassign bool, targetID *uint) error {
 	// 1. 检查是否存在
 	_, err := s.repo.GetByID(ctx, id)
 	if err != nil {
@@ -89,20 +134,218 @@ func (s *CategoryService) Delete(ctx context.Context, id uint) error {
 		return err
 	}
 	
-	// 2. 检查是否有文章使用该分类
-	count, err := s.repo.CountArticles(ctx, id)
+	// 2. 检查是否有子分类
+	children, err := s.repo.CountChildren(ctx, id)
+	if err != nil {
+		return err
+	}
+	if children > 0 {
+		return errors.New("该分类下有子分类，无法删除")
+	}
+	
+	// 3. 不转移文章时，检查是否有文章使用该分类
+	if !reassign {
+		count, err := s.repo.CountArticles(ctx, id)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return errors. New("该分类下有文章，无法删除")
+		}
+		return s.repo.Delete(ctx, id)
+	}
+	
+	// 4. 检查目标分类
+	if targetID != nil {
+		if *targetID == id {
+			return errors.New("目标分类不能是待删除的分类")
+		}
+		if _, err := s.repo.GetByID(ctx, *targetID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("目标分类不存在")
+			}
+			return err
+		}
+	}
+	
+	// 5. 转移文章并删除
+	return s.repo.MoveAndDelete(ctx, id, targetID)
+}
+
+// Merge 将分类合并到目标分类：文章和子分类全部移到目标分类下，然后删除源分类
+func (s *CategoryService) Merge(ctx context.Context, id, targetID uint) error {
+	// 1. 检查分类是否存在
+	if id == targetID {
+		return errors.New("不能合并到自身")
+	}
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("分类不存在")
+		}
+		return err
+	}
+	if _, err := s.repo.GetByID(ctx, targetID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("目标分类不存在")
+		}
+		return err
+	}
+	
+	// 2. 目标分类不能是源分类的子孙（否则子分类移动后会形成环）
+	categories, err := s.repo.List(ctx)
 	if err != nil {
 		return err
 	}
-	if count > 0 {
-		return errors. New("该分类下有文章，无法删除")
+	for _, descendant := range categoryDescendants(categories, id) {
+		if descendant == targetID {
+			return errors.New("不能合并到其子分类")
+		}
 	}
 	
-	// 3. 删除
-	return s.repo.Delete(ctx, id)
+	// 3. 转移文章和子分类并删除
+	return s.repo.MoveAndDelete(ctx, id, &targetID)
 }
 
 // ListWithCount 获取分类列表（带文章数量）
 func (s *CategoryService) ListWithCount(ctx context.Context) ([]map[string]interface{}, error) {
 	return s.repo.ListWithArticleCount(ctx)
-}
\ No newline at end of file
+}
+
+// Tree 获取完整的分类树（同级按排序值排列）
+func (s *CategoryService) Tree(ctx context.Context) ([]*model.Category, error) {
+	categories, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return buildCategoryTree(categories), nil
+}
+
+// validateParent 检查父分类是否存在，且不是分类自身或其子孙分类（避免形成环）
+func (s *CategoryService) validateParent(ctx context.Context, category *model.Category) error {
+	if category.ParentID == nil {
+		return nil
+	}
+	if *category.ParentID == category.ID {
+		return errors.New("不能将分类设为自身的子分类")
+	}
+	
+	categories, err := s.repo.List(ctx)
+	if err != nil {
+		return err
+	}
+	byID := make(map[uint]*model.Category, len(categories))
+	for _, c := range categories {
+		byID[c.ID] = c
+	}
+	if byID[*category.ParentID] == nil {
+		return errors.New("父分类不存在")
+	}
+	
+	// 从父分类向上查找，遇到当前分类说明父分类是它的子孙
+	visited := make(map[uint]bool)
+	for parent := byID[*category.ParentID]; parent != nil && !visited[parent.ID]; {
+		if parent.ID == category.ID {
+			return errors.New("不能将分类移动到其子分类下")
+		}
+		visited[parent.ID] = true
+		if parent.ParentID == nil {
+			break
+		}
+		parent = byID[*parent.ParentID]
+	}
+	return nil
+}
+
+// prepareSlug 规范化并校验 URL 别名（可为空，空别名保存为 NULL；不能与其他分类重复）
+func (s *CategoryService) prepareSlug(ctx context.Context, category *model.Category) error {
+	category.Slug = normalizeSlug(category.Slug)
+	if category.Slug == nil {
+		return nil
+	}
+	if !slugPattern.MatchString(*category.Slug) {
+		return errors.New("URL 别名只能包含小写字母、数字和连字符")
+	}
+	existing, err := s.repo.GetBySlug(ctx, *category.Slug)
+	if err == nil && existing.ID != category.ID {
+		return errors.New("URL 别名已被其他分类使用")
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
+
+// normalizeSlug 规范化分类和标签的 URL 别名（转小写、去除空白），为空时返回 nil
+func normalizeSlug(slug *string) *string {
+	if slug == nil {
+		return nil
+	}
+	normalized := strings.ToLower(strings.TrimSpace(*slug))
+	if normalized == "" {
+		return nil
+	}
+	return &normalized
+}
+
+// buildCategoryTree 根据 ParentID 组装分类树（父分类不存在的视为顶级分类）
+func buildCategoryTree(categories []*model.Category) []*model.Category {
+	byID := make(map[uint]*model.Category, len(categories))
+	for _, c := range categories {
+		c.Children = nil
+		byID[c.ID] = c
+	}
+	
+	var roots []*model.Category
+	for _, c := range categories {
+		if c.ParentID != nil {
+			if parent, ok := byID[*c.ParentID]; ok && parent != c {
+				parent.Children = append(parent.Children, c)
+				continue
+			}
+		}
+		roots = append(roots, c)
+	}
+	return roots
+}
+
+// categoryBreadcrumbs 返回从顶级分类到指定分类的路径
+func categoryBreadcrumbs(categories []*model.Category, id uint) []model.CategoryBreadcrumb {
+	byID := make(map[uint]*model.Category, len(categories))
+	for _, c := range categories {
+		byID[c.ID] = c
+	}
+	
+	var path []model.CategoryBreadcrumb
+	visited := make(map[uint]bool)
+	for c := byID[id]; c != nil && !visited[c.ID]; {
+		visited[c.ID] = true
+		path = append([]model.CategoryBreadcrumb{{ID: c.ID, Name: c.Name}}, path...)
+		if c.ParentID == nil {
+			break
+		}
+		c = byID[*c.ParentID]
+	}
+	return path
+}
+
+// categoryDescendants 返回指定分类及其所有子孙分类的 ID
+func categoryDescendants(categories []*model.Category, id uint) []uint {
+	children := make(map[uint][]uint)
+	for _, c := range categories {
+		if c.ParentID != nil {
+			children[*c.ParentID] = append(children[*c.ParentID], c.ID)
+		}
+	}
+	
+	ids := []uint{id}
+	visited := map[uint]bool{id: true}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range children[ids[i]] {
+			if !visited[child] {
+				visited[child] = true
+				ids = append(ids, child)
+			}
+		}
+	}
+	return ids
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/zyy125/my-blog/backend/internal/model"
+	"github.com/zyy125/my-blog/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// fieldNamePattern 自定义字段名格式（同时用于 JSON 路径，必须严格限制）
+var fieldNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// CustomFieldService 自定义字段业务逻辑层
+type CustomFieldService struct {
+	repo *repository.CustomFieldRepository
+}
+
+// NewCustomFieldService 创建自定义字段服务实例
+func NewCustomFieldService(repo *repository.CustomFieldRepository) *CustomFieldService {
+	return &CustomFieldService{repo: repo}
+}
+
+// Create 创建自定义字段
+func (s *CustomFieldService) Create(ctx context.Context, field *model.CustomField) error {
+	// 1. 验证
+	if !fieldNamePattern.MatchString(field.Name) {
+		return errors.New("字段名只能包含小写字母、数字和下划线，且以字母开头")
+	}
+	if err := validateFieldDefinition(field); err != nil {
+		return err
+	}
+
+	// 2. 检查是否已存在同名字段
+	existing, err := s.repo.GetByName(ctx, field.Name)
+	if err == nil && existing != nil {
+		return errors.New("字段名已存在")
+	}
+
+	// 3. 创建
+	return s.repo.Create(ctx, field)
+}
+
+// List 获取所有自定义字段
+func (s *CustomFieldService) List(ctx context.Context) ([]*model.CustomField, error) {
+	return s.repo.List(ctx)
+}
+
+// Update 更新自定义字段（字段名不可修改）
+func (s *CustomFieldService) Update(ctx context.Context, field *model.CustomField) error {
+	// 1. 检查是否存在
+	existing, err := s.repo.GetByID(ctx, field.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("自定义字段不存在")
+		}
+		return err
+	}
+
+	// 2. 验证
+	if err := validateFieldDefinition(field); err != nil {
+		return err
+	}
+
+	// 3. 保留字段名和创建时间
+	field.Name = existing.Name
+	field.CreatedAt = existing.CreatedAt
+
+	// 4. 更新
+	return s.repo.Update(ctx, field)
+}
+
+// Delete 删除自定义字段（同时移除文章中的该字段值）
+func (s *CustomFieldService) Delete(ctx context.Context, id uint) error {
+	field, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("自定义字段不存在")
+		}
+		return err
+	}
+
+	return s.repo.Delete(ctx, field)
+}
+
+// validateFieldDefinition 验证字段定义本身
+func validateFieldDefinition(field *model.CustomField) error {
+	switch field.Type {
+	case model.CustomFieldString, model.CustomFieldNumber, model.CustomFieldBoolean, model.CustomFieldURL:
+	case model.CustomFieldSelect:
+		if len(field.Options) == 0 {
+			return errors.New("单选字段必须提供可选值")
+		}
+	default:
+		return errors.New("不支持的字段类型")
+	}
+
+	if field.Pattern != "" {
+		if _, err := regexp.Compile(field.Pattern); err != nil {
+			return errors.New("正则表达式格式错误")
+		}
+	}
+	if field.MaxLength < 0 {
+		return errors.New("最大长度不能为负数")
+	}
+	return nil
+}
+
+// validateMetadata 按字段定义校验文章元数据，返回规范化后的元数据
+func validateMetadata(fields []*model.CustomField, metadata model.JSONMap) (model.JSONMap, error) {
+	defs := make(map[string]*model.CustomField, len(fields))
+	for _, field := range fields {
+		defs[field.Name] = field
+	}
+
+	// 1. 不允许未定义的字段
+	for key := range metadata {
+		if _, ok := defs[key]; !ok {
+			return nil, fmt.Errorf("未定义的自定义字段: %s", key)
+		}
+	}
+
+	// 2. 逐个字段校验
+	result := make(model.JSONMap, len(metadata))
+	for _, field := range fields {
+		value, exists := metadata[field.Name]
+		if !exists || value == nil || value == "" {
+			if field.Required {
+				return nil, fmt.Errorf("自定义字段 %s 不能为空", fieldLabel(field))
+			}
+			continue
+		}
+
+		normalized, err := validateFieldValue(field, value)
+		if err != nil {
+			return nil, err
+		}
+		result[field.Name] = normalized
+	}
+	return result, nil
+}
+
+// validateFieldValue 校验单个字段值
+func validateFieldValue(field *model.CustomField, value interface{}) (interface{}, error) {
+	label := fieldLabel(field)
+
+	switch field.Type {
+	case model.CustomFieldNumber:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case string:
+			n, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, fmt.Errorf("自定义字段 %s 必须是数字", label)
+			}
+			return n, nil
+		}
+		return nil, fmt.Errorf("自定义字段 %s 必须是数字", label)
+
+	case model.CustomFieldBoolean:
+		if v, ok := value.(bool); ok {
+			return v, nil
+		}
+		return nil, fmt.Errorf("自定义字段 %s 必须是布尔值", label)
+	}
+
+	// 以下类型均为字符串
+	str, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("自定义字段 %s 必须是字符串", label)
+	}
+
+	switch field.Type {
+	case model.CustomFieldURL:
+		u, err := url.Parse(str)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("自定义字段 %s 必须是 http(s) 链接", label)
+		}
+	case model.CustomFieldSelect:
+		for _, option := range field.Options {
+			if option == str {
+				return str, nil
+			}
+		}
+		return nil, fmt.Errorf("自定义字段 %s 的值不在可选范围内", label)
+	}
+
+	if field.MaxLength > 0 && utf8.RuneCountInString(str) > field.MaxLength {
+		return nil, fmt.Errorf("自定义字段 %s 最多 %d 个字符", label, field.MaxLength)
+	}
+	if field.Pattern != "" {
+		re, err := regexp.Compile(field.Pattern)
+		if err != nil || !re.MatchString(str) {
+			return nil, fmt.Errorf("自定义字段 %s 格式不正确", label)
+		}
+	}
+	return str, nil
+}
+
+// fieldLabel 返回字段的显示名称
+func fieldLabel(field *model.CustomField) string {
+	if field.Label != "" {
+		return field.Label
+	}
+	return field.Name
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/zyy125/my-blog/backend/internal/model"
+)
+
+// normalizeLanguage 归一化语言代码（小写，下划线转连字符），如 zh_CN -> zh-cn
+func normalizeLanguage(language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	return strings.ReplaceAll(language, "_", "-")
+}
+
+// baseLanguage 取语言代码的主语言部分，如 zh-cn -> zh
+func baseLanguage(language string) string {
+	if i := strings.Index(language, "-"); i > 0 {
+		return language[:i]
+	}
+	return language
+}
+
+// pickTranslation 按语言偏好从多个语言版本中选择一篇
+// 依次尝试完全匹配、主语言匹配，都没有时返回原文（翻译组 ID 对应的文章）
+func pickTranslation(articles []*model.Article, preferred []string) *model.Article {
+	for _, pref := range preferred {
+		pref = normalizeLanguage(pref)
+		if pref == "" {
+			continue
+		}
+		for _, article := range articles {
+			if article.Language == pref {
+				return article
+			}
+		}
+		for _, article := range articles {
+			if baseLanguage(article.Language) == baseLanguage(pref) {
+				return article
+			}
+		}
+	}
+
+	for _, article := range articles {
+		if article.ID == article.TranslationGroup() {
+			return article
+		}
+	}
+	return articles[0]
+}
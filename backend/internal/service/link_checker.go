@@ -0,0 +1,471 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zyy125/my-blog/backend/internal/model"
+	"github.com/zyy125/my-blog/backend/internal/pkg/linkcheck"
+	"github.com/zyy125/my-blog/backend/internal/pkg/storage"
+	"github.com/zyy125/my-blog/backend/internal/pkg/upload"
+	"github.com/zyy125/my-blog/backend/internal/repository"
+)
+
+const (
+	defaultLinkCheckInterval    = 24 * time.Hour
+	defaultLinkCheckConcurrency = 5
+)
+
+// articlePathPattern 站内文章链接（前端路由 /article/:id）
+var articlePathPattern = regexp.MustCompile(`^/article/(\d+)/?$`)
+
+// LinkCheckOptions 死链检查任务配置
+type LinkCheckOptions struct {
+	Interval    time.Duration   // 定时检查间隔
+	Timeout     time.Duration   // 外部链接请求超时
+	Concurrency int             // 外部链接并发检查数
+	UserAgent   string          // 请求外部链接时的 User-Agent
+	SiteURL     string          // 本站地址
+	Storage     storage.Storage // 上传文件存储
+}
+
+// LinkCheckStatus 死链检查任务状态
+type LinkCheckStatus struct {
+	Running        bool       `json:"running"`
+	LastStartedAt  *time.Time `json:"last_started_at"`
+	LastFinishedAt *time.Time `json:"last_finished_at"`
+	LastError      string     `json:"last_error,omitempty"`
+	Articles       int        `json:"articles"` // 检查的文章数
+	Pages          int        `json:"pages"`    // 检查的页面数
+	Links          int        `json:"links"`    // 检查的链接数
+	Broken         int        `json:"broken"`   // 失效链接数
+}
+
+// ArticleBrokenLinks 单篇文章的死链
+type ArticleBrokenLinks struct {
+	ArticleID uint                `json:"article_id"`
+	Title     string              `json:"title"`
+	Links     []*model.BrokenLink `json:"links"`
+}
+
+// PageBrokenLinks 单个页面的死链
+type PageBrokenLinks struct {
+	PageID uint                `json:"page_id"`
+	Title  string              `json:"title"`
+	Links  []*model.BrokenLink `json:"links"`
+}
+
+// LinkCheckReport 死链报告
+type LinkCheckReport struct {
+	Status   LinkCheckStatus       `json:"status"`
+	Articles []*ArticleBrokenLinks `json:"articles"`
+	Pages    []*PageBrokenLinks    `json:"pages"`
+}
+
+// linkResult 单个链接的检查结果
+type linkResult struct {
+	broken     bool
+	statusCode int
+	err        string
+}
+
+// LinkChecker 文章和页面死链检查后台任务
+// 定时解析所有已发布文章和页面 Markdown 中的链接和图片：站内文章链接对照数据库，
+// 上传文件对照存储后端，外部链接发送 HEAD 请求，失效结果写入数据库
+type LinkChecker struct {
+	articleRepo *repository.ArticleRepository
+	pageRepo    *repository.PageRepository
+	linkRepo    *repository.BrokenLinkRepository
+	checker     *linkcheck.Checker
+	interval    time.Duration
+	concurrency int
+	siteHost    string
+	storage     storage.Storage
+
+	mu     sync.Mutex
+	status LinkCheckStatus
+
+	ctx      context.Context
+	cancel   context.CancelFunc
+	trigger  chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewLinkChecker 创建死链检查任务并启动定时协程
+func NewLinkChecker(articleRepo *repository.ArticleRepository, pageRepo *repository.PageRepository, linkRepo *repository.BrokenLinkRepository, opts LinkCheckOptions) *LinkChecker {
+	if opts.Interval <= 0 {
+		opts.Interval = defaultLinkCheckInterval
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = defaultLinkCheckConcurrency
+	}
+	if opts.Storage == nil {
+		opts.Storage = upload.DefaultConfig.Storage
+	}
+
+	siteHost := ""
+	if u, err := url.Parse(opts.SiteURL); err == nil {
+		siteHost = strings.ToLower(u.Host)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	lc := &LinkChecker{
+		articleRepo: articleRepo,
+		pageRepo:    pageRepo,
+		linkRepo:    linkRepo,
+		checker:     linkcheck.NewChecker(opts.Timeout, opts.UserAgent),
+		interval:    opts.Interval,
+		concurrency: opts.Concurrency,
+		siteHost:    siteHost,
+		storage:     opts.Storage,
+		ctx:         ctx,
+		cancel:      cancel,
+		trigger:     make(chan struct{}, 1),
+		done:        make(chan struct{}),
+	}
+	go lc.run()
+	return lc
+}
+
+// Stop 停止定时任务并中断正在进行的检查（优雅退出时调用）
+func (lc *LinkChecker) Stop() {
+	lc.stopOnce.Do(func() {
+		lc.cancel()
+		<-lc.done
+	})
+}
+
+// Trigger 立即触发一次检查，已在检查中时返回 false
+func (lc *LinkChecker) Trigger() bool {
+	lc.mu.Lock()
+	running := lc.status.Running
+	lc.mu.Unlock()
+	if running {
+		return false
+	}
+
+	select {
+	case lc.trigger <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Status 获取任务状态
+func (lc *LinkChecker) Status() LinkCheckStatus {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	return lc.status
+}
+
+// Report 获取死链报告（articleID 为 nil 时返回全部文章和页面）
+func (lc *LinkChecker) Report(ctx context.Context, articleID *uint) (*LinkCheckReport, error) {
+	links, err := lc.linkRepo.List(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 按文章和页面分组（记录已按页面ID、文章ID排序）
+	report := &LinkCheckReport{
+		Status:   lc.Status(),
+		Articles: make([]*ArticleBrokenLinks, 0),
+		Pages:    make([]*PageBrokenLinks, 0),
+	}
+	var article *ArticleBrokenLinks
+	var page *PageBrokenLinks
+	for _, link := range links {
+		if link.PageID != 0 {
+			if page == nil || page.PageID != link.PageID {
+				page = &PageBrokenLinks{PageID: link.PageID, Title: link.Title}
+				report.Pages = append(report.Pages, page)
+			}
+			page.Links = append(page.Links, link)
+			continue
+		}
+
+		if article == nil || article.ArticleID != link.ArticleID {
+			article = &ArticleBrokenLinks{ArticleID: link.ArticleID, Title: link.Title}
+			report.Articles = append(report.Articles, article)
+		}
+		article.Links = append(article.Links, link)
+	}
+	return report, nil
+}
+
+// Run 执行一次完整检查
+func (lc *LinkChecker) Run(ctx context.Context) error {
+	// 1. 标记开始
+	lc.mu.Lock()
+	if lc.status.Running {
+		lc.mu.Unlock()
+		return errors.New("链接检查正在进行中")
+	}
+	startedAt := time.Now()
+	lc.status.Running = true
+	lc.status.LastStartedAt = &startedAt
+	lc.mu.Unlock()
+
+	articles, pages, links, broken, err := lc.check(ctx)
+
+	// 2. 记录结果
+	finishedAt := time.Now()
+	lc.mu.Lock()
+	lc.status.Running = false
+	lc.status.LastFinishedAt = &finishedAt
+	lc.status.LastError = ""
+	if err != nil {
+		lc.status.LastError = err.Error()
+	} else {
+		lc.status.Articles = articles
+		lc.status.Pages = pages
+		lc.status.Links = links
+		lc.status.Broken = broken
+	}
+	lc.mu.Unlock()
+
+	return err
+}
+
+// linkSource 待检查链接的来源（文章或页面）
+type linkSource struct {
+	articleID uint
+	pageID    uint
+	links     []linkcheck.Link
+}
+
+// check 检查所有已发布文章和页面，返回文章数、页面数、链接数和失效链接数
+func (lc *LinkChecker) check(ctx context.Context) (int, int, int, int, error) {
+	// 1. 加载已发布文章和页面
+	articles, err := lc.articleRepo.ListPublishedContent(ctx)
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+	pages, err := lc.pageRepo.ListPublishedContent(ctx)
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+
+	published := make(map[uint]bool, len(articles))
+	sources := make([]linkSource, 0, len(articles)+len(pages))
+	for _, article := range articles {
+		published[article.ID] = true
+		links := linkcheck.Extract(article.Content)
+		if article.CoverImg != "" {
+			links = append(links, linkcheck.Link{URL: article.CoverImg, Kind: linkcheck.KindImage})
+		}
+		sources = append(sources, linkSource{articleID: article.ID, links: links})
+	}
+	for _, page := range pages {
+		sources = append(sources, linkSource{pageID: page.ID, links: linkcheck.Extract(page.Content)})
+	}
+
+	// 2. 检查站内链接，外部链接去重后统一检查
+	type sourceLink struct {
+		source   *linkSource
+		link     linkcheck.Link
+		internal bool
+	}
+	var pending []sourceLink
+	results := make(map[string]linkResult)
+	external := make(map[string]bool)
+
+	for i := range sources {
+		source := &sources[i]
+		for _, link := range source.links {
+			// 上传文件（S3 等存储的地址可能是外部域名，先按存储地址识别）
+			if key, isUpload := lc.storage.KeyFromURL(link.URL); isUpload {
+				results[link.URL] = lc.checkUpload(ctx, key)
+				pending = append(pending, sourceLink{source: source, link: link, internal: true})
+				continue
+			}
+
+			target, internal, ok := lc.classify(link.URL)
+			if !ok {
+				continue
+			}
+			if internal {
+				result, checked := lc.checkInternal(ctx, target, published)
+				if !checked {
+					continue
+				}
+				results[link.URL] = result
+			} else {
+				external[target] = true
+			}
+			pending = append(pending, sourceLink{source: source, link: link, internal: internal})
+		}
+	}
+
+	for target, result := range lc.checkExternal(ctx, external) {
+		results[target] = result
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, 0, 0, 0, err
+	}
+
+	// 3. 汇总失效链接并写入数据库
+	now := time.Now()
+	var broken []model.BrokenLink
+	for _, item := range pending {
+		result := results[item.link.URL]
+		if !result.broken {
+			continue
+		}
+		broken = append(broken, model.BrokenLink{
+			ArticleID:  item.source.articleID,
+			PageID:     item.source.pageID,
+			URL:        item.link.URL,
+			Kind:       item.link.Kind,
+			Internal:   item.internal,
+			StatusCode: result.statusCode,
+			Error:      result.err,
+			CheckedAt:  now,
+		})
+	}
+	if err := lc.linkRepo.ReplaceAll(ctx, broken); err != nil {
+		return 0, 0, 0, 0, err
+	}
+
+	return len(articles), len(pages), len(pending), len(broken), nil
+}
+
+// classify 判断链接类型，返回站内路径或外部 URL；锚点、mailto 等无需检查的返回 ok=false
+func (lc *LinkChecker) classify(raw string) (target string, internal bool, ok bool) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", false, false
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "":
+		// 相对链接：只检查以 / 开头的站内路径
+		if u.Host == "" && strings.HasPrefix(u.Path, "/") {
+			return u.Path, true, true
+		}
+		return "", false, false
+	case "http", "https":
+		if lc.siteHost != "" && strings.ToLower(u.Host) == lc.siteHost {
+			return u.Path, true, true
+		}
+		return raw, false, true
+	default:
+		return "", false, false
+	}
+}
+
+// checkInternal 检查站内链接，只能识别文章、上传文件和附件下载地址，其他路径返回 checked=false
+func (lc *LinkChecker) checkInternal(ctx context.Context, path string, published map[uint]bool) (result linkResult, checked bool) {
+	// 文章链接
+	if match := articlePathPattern.FindStringSubmatch(path); match != nil {
+		id, err := strconv.ParseUint(match[1], 10, 32)
+		if err != nil || !published[uint(id)] {
+			return linkResult{broken: true, err: "文章不存在或未发布"}, true
+		}
+		return linkResult{}, true
+	}
+
+	// 上传文件（带本站域名的绝对地址）
+	if key, ok := lc.storage.KeyFromURL(path); ok {
+		return lc.checkUpload(ctx, key), true
+	}
+
+	// 附件下载地址
+	if key, ok := upload.KeyFromDownloadURL(path); ok {
+		return lc.checkUpload(ctx, key), true
+	}
+
+	return linkResult{}, false
+}
+
+// checkUpload 检查上传文件是否存在
+func (lc *LinkChecker) checkUpload(ctx context.Context, key string) linkResult {
+	_, err := lc.storage.Stat(ctx, key)
+	if errors.Is(err, fs.ErrNotExist) {
+		return linkResult{broken: true, err: "文件不存在"}
+	}
+	if err != nil {
+		return linkResult{broken: true, err: truncateError(err)}
+	}
+	return linkResult{}
+}
+
+// checkExternal 并发检查外部链接
+func (lc *LinkChecker) checkExternal(ctx context.Context, urls map[string]bool) map[string]linkResult {
+	results := make(map[string]linkResult, len(urls))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, lc.concurrency)
+
+	for target := range urls {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return results
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(target string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			var result linkResult
+			status, err := lc.checker.Check(ctx, target)
+			if err != nil {
+				result = linkResult{broken: true, err: truncateError(err)}
+			} else if linkcheck.IsBroken(status) {
+				result = linkResult{broken: true, statusCode: status, err: fmt.Sprintf("HTTP %d", status)}
+			} else {
+				result = linkResult{statusCode: status}
+			}
+
+			mu.Lock()
+			results[target] = result
+			mu.Unlock()
+		}(target)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// run 定时检查循环
+func (lc *LinkChecker) run() {
+	defer close(lc.done)
+
+	ticker := time.NewTicker(lc.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-lc.trigger:
+		case <-lc.ctx.Done():
+			return
+		}
+
+		if err := lc.Run(lc.ctx); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("链接检查失败: %v", err)
+		}
+	}
+}
+
+// truncateError 截断错误信息以适配数据库字段长度
+func truncateError(err error) string {
+	msg := err.Error()
+	if len(msg) > 255 {
+		msg = msg[:255]
+	}
+	return msg
+}
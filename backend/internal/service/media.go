@@ -0,0 +1,419 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/zyy125/my-blog/backend/internal/model"
+	"github.com/zyy125/my-blog/backend/internal/pkg/storage"
+	"github.com/zyy125/my-blog/backend/internal/pkg/upload"
+	"github.com/zyy125/my-blog/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// MediaCleanupResult 孤立文件清理结果
+type MediaCleanupResult struct {
+	DryRun  bool     `json:"dry_run"` // 仅预览，未实际删除
+	Files   []string `json:"files"`   // 孤立文件的访问路径
+	Bytes   int64    `json:"bytes"`   // 孤立文件总大小
+	Records int      `json:"records"` // 需要删除的媒体记录数（包括文件已丢失的记录）
+}
+
+// usageMonths 存储用量按月统计的月数
+const usageMonths = 12
+
+// largestFilesLimit 存储用量中列出的最大文件数
+const largestFilesLimit = 10
+
+// StorageUsage 上传存储用量
+type StorageUsage struct {
+	TotalBytes int64                     `json:"total_bytes"` // 存储中所有文件（含衍生版本）的总大小
+	TotalFiles int64                     `json:"total_files"` // 存储中的文件数（含衍生版本）
+	Quota      int64                     `json:"quota"`       // 配额（字节），0 表示不限制
+	ByMonth    []repository.MonthlyUsage `json:"by_month"`    // 最近 12 个月每月上传量（不含衍生版本）
+	ByType     []CategoryUsage           `json:"by_type"`     // 按文件类别统计（不含衍生版本）
+	Largest    []*model.Media            `json:"largest"`     // 最大的文件
+}
+
+// CategoryUsage 某类文件的用量
+type CategoryUsage struct {
+	Category  string                     `json:"category"` // 文件类别（image/document/archive/audio/other）
+	Files     int64                      `json:"files"`
+	Bytes     int64                      `json:"bytes"`
+	MimeTypes []repository.MimeTypeUsage `json:"mime_types"` // 各 MIME 类型明细
+}
+
+// MediaService 媒体库业务逻辑层
+type MediaService struct {
+	repo       *repository.MediaRepository
+	config     *upload.UploadConfig
+	refPattern *regexp.Regexp // 内容中引用上传文件的地址
+}
+
+// NewMediaService 创建媒体服务实例
+func NewMediaService(repo *repository.MediaRepository) *MediaService {
+	config := upload.DefaultConfig
+	// 本地存储的地址前缀为 /uploads/，带域名的绝对地址同样能匹配到路径部分；
+	// 附件通常以下载地址（/download/...）引用
+	prefix := regexp.QuoteMeta(config.Storage.URL(""))
+	download := regexp.QuoteMeta(upload.DownloadPrefix)
+	return &MediaService{
+		repo:       repo,
+		config:     config,
+		refPattern: regexp.MustCompile(`(?:` + prefix + `|` + download + `)[^\s"'()<>\[\]?#]+`),
+	}
+}
+
+// Record 记录一次上传；相同内容已有记录时只增加引用计数，返回已有记录
+func (s *MediaService) Record(ctx context.Context, info *upload.FileInfo, uploaderID *uint) (*model.Media, error) {
+	media := &model.Media{
+		Path:       info.URL,
+		FileName:   info.Name,
+		Size:       info.Size,
+		MimeType:   info.MimeType,
+		Width:      info.Width,
+		Height:     info.Height,
+		Hash:       info.Hash,
+		UploaderID: uploaderID,
+		UploadedAt: time.Now(),
+	}
+	for _, v := range info.Variants {
+		media.Variants = append(media.Variants, model.MediaVariant{
+			Name:   v.Name,
+			URL:    v.URL,
+			Width:  v.Width,
+			Height: v.Height,
+		})
+	}
+	return s.repo.CreateOrAddRef(ctx, media)
+}
+
+// List 分页查询媒体，并附带引用信息
+// fileType 可以是 MIME 大类（如 image）或完整 MIME 类型
+func (s *MediaService) List(ctx context.Context, page, pageSize int, keyword, fileType string) ([]*model.Media, int64, error) {
+	// 1. 参数校验
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	mimePrefix := strings.ToLower(strings.TrimSpace(fileType))
+	if mimePrefix != "" && !strings.Contains(mimePrefix, "/") {
+		mimePrefix += "/"
+	}
+
+	// 2. 查询
+	list, total, err := s.repo.List(ctx, page, pageSize, strings.TrimSpace(keyword), mimePrefix)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// 3. 附带引用信息（只查询引用了本页文件的内容）
+	refs, err := s.referenceIndex(ctx, list)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, media := range list {
+		media.References = mediaReferences(refs, media)
+		s.attachDownloadURL(media)
+	}
+
+	return list, total, nil
+}
+
+// GetByID 获取媒体详情（附带引用信息）
+func (s *MediaService) GetByID(ctx context.Context, id uint) (*model.Media, error) {
+	media, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("文件不存在")
+		}
+		return nil, err
+	}
+
+	refs, err := s.referenceIndex(ctx, []*model.Media{media})
+	if err != nil {
+		return nil, err
+	}
+	media.References = mediaReferences(refs, media)
+	s.attachDownloadURL(media)
+	return media, nil
+}
+
+// Open 打开附件用于下载，只能下载媒体库中记录的文件
+func (s *MediaService) Open(ctx context.Context, key string) (*model.Media, io.ReadCloser, *storage.ObjectInfo, error) {
+	media, err := s.repo.GetByPath(ctx, s.config.Storage.URL(key))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, nil, errors.New("文件不存在")
+		}
+		return nil, nil, nil, err
+	}
+
+	body, info, err := s.config.Storage.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil, nil, errors.New("文件不存在")
+		}
+		return nil, nil, nil, err
+	}
+	return media, body, info, nil
+}
+
+// Usage 统计上传存储用量
+func (s *MediaService) Usage(ctx context.Context) (*StorageUsage, error) {
+	// 1. 存储总用量（由 upload 包实时累计）
+	bytes, files, err := s.config.Usage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	usage := &StorageUsage{TotalBytes: bytes, TotalFiles: files, Quota: s.config.Quota}
+
+	// 2. 按月统计（补齐没有上传的月份）
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month()-usageMonths+1, 1, 0, 0, 0, 0, now.Location())
+	rows, err := s.repo.UsageByMonth(ctx, start)
+	if err != nil {
+		return nil, err
+	}
+	byMonth := make(map[string]repository.MonthlyUsage, len(rows))
+	for _, row := range rows {
+		byMonth[row.Month] = row
+	}
+	for i := 0; i < usageMonths; i++ {
+		month := start.AddDate(0, i, 0).Format("2006-01")
+		row, ok := byMonth[month]
+		if !ok {
+			row = repository.MonthlyUsage{Month: month}
+		}
+		usage.ByMonth = append(usage.ByMonth, row)
+	}
+
+	// 3. 按文件类别统计
+	types, err := s.repo.UsageByMimeType(ctx)
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[string]int) // 类别 -> ByType 下标
+	for _, row := range types {
+		category := "other"
+		if t := upload.TypeByMIME(row.MimeType); t != nil {
+			category = t.Category
+		}
+		i, ok := index[category]
+		if !ok {
+			i = len(usage.ByType)
+			index[category] = i
+			usage.ByType = append(usage.ByType, CategoryUsage{Category: category})
+		}
+		item := &usage.ByType[i]
+		item.Files += row.Files
+		item.Bytes += row.Bytes
+		item.MimeTypes = append(item.MimeTypes, row)
+	}
+
+	// 4. 最大的文件
+	usage.Largest, err = s.repo.Largest(ctx, largestFilesLimit)
+	if err != nil {
+		return nil, err
+	}
+	for _, media := range usage.Largest {
+		s.attachDownloadURL(media)
+	}
+
+	return usage, nil
+}
+
+// CountDownload 记录一次下载
+func (s *MediaService) CountDownload(ctx context.Context, id uint) error {
+	return s.repo.IncrementDownloads(ctx, id)
+}
+
+// attachDownloadURL 为非图片文件填充下载地址
+func (s *MediaService) attachDownloadURL(media *model.Media) {
+	if strings.HasPrefix(media.MimeType, "image/") {
+		return
+	}
+	if key, ok := s.config.Storage.KeyFromURL(media.Path); ok {
+		media.DownloadURL = upload.DownloadURL(key)
+	}
+}
+
+// Delete 删除一次上传：同一内容被多次上传时只减少引用计数，
+// 最后一次引用才删除文件和记录；仍被内容引用时需要 force 才能删除
+func (s *MediaService) Delete(ctx context.Context, id uint, force bool) error {
+	// 1. 检查是否存在
+	media, err := s.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// 2. 还有其他上传使用同一文件时只减少引用计数，文件保留
+	released, err := s.repo.ReleaseRef(ctx, id)
+	if err != nil {
+		return err
+	}
+	if released {
+		return nil
+	}
+
+	// 3. 最后一次引用，检查内容引用情况
+	if len(media.References) > 0 && !force {
+		ref := media.References[0]
+		return fmt.Errorf("文件仍被 %d 处内容引用（如 %s #%d %s），无法删除", len(media.References), ref.Type, ref.ID, ref.Title)
+	}
+
+	// 4. 删除存储中的文件（包括衍生版本）
+	for _, url := range append([]string{media.Path}, media.Variants.URLs()...) {
+		if err := upload.Remove(ctx, s.config, url); err != nil {
+			return fmt.Errorf("删除文件失败: %v", err)
+		}
+	}
+
+	// 5. 删除记录
+	return s.repo.Delete(ctx, id)
+}
+
+// CleanupOrphans 清理没有被任何内容引用的上传文件
+// 原图和衍生版本视为一组，任一被引用则整组保留；
+// 只处理修改时间早于 minAge 之前的文件，避免误删刚上传但尚未保存到文章中的图片
+func (s *MediaService) CleanupOrphans(ctx context.Context, minAge time.Duration, dryRun bool) (*MediaCleanupResult, error) {
+	// 1. 建立全部内容的引用索引，并把衍生版本归到原图
+	refs, err := s.referenceIndex(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.repo.ListPaths(ctx)
+	if err != nil {
+		return nil, err
+	}
+	owner := make(map[string]string)    // 文件路径 -> 原图路径
+	referenced := make(map[string]bool) // 原图路径 -> 整组是否被引用
+	for _, media := range records {
+		owner[media.Path] = media.Path
+		for _, url := range media.Variants.URLs() {
+			owner[url] = media.Path
+		}
+		referenced[media.Path] = len(mediaReferences(refs, media)) > 0
+	}
+	isReferenced := func(url string) bool {
+		if path, ok := owner[url]; ok {
+			return referenced[path]
+		}
+		return len(refs[url]) > 0
+	}
+
+	cutoff := time.Now().Add(-minAge)
+	result := &MediaCleanupResult{DryRun: dryRun, Files: make([]string, 0)}
+
+	// 2. 遍历存储，找出未被引用的文件
+	stored := make(map[string]bool)
+	err = s.config.Storage.List(ctx, func(obj storage.ObjectInfo) error {
+		url := s.config.Storage.URL(obj.Key)
+		stored[url] = true
+		if isReferenced(url) || obj.ModTime.After(cutoff) {
+			return nil
+		}
+		result.Files = append(result.Files, url)
+		result.Bytes += obj.Size
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("遍历上传文件失败: %v", err)
+	}
+
+	// 3. 需要删除的记录：原图是孤立文件的记录，以及文件已丢失且未被引用的记录
+	orphan := make(map[string]bool, len(result.Files))
+	for _, url := range result.Files {
+		orphan[url] = true
+	}
+	var stale []string
+	for _, media := range records {
+		missing := !stored[media.Path] && !referenced[media.Path] && media.UploadedAt.Before(cutoff)
+		if orphan[media.Path] || missing {
+			stale = append(stale, media.Path)
+		}
+	}
+	result.Records = len(stale)
+
+	if dryRun {
+		return result, nil
+	}
+
+	// 4. 删除文件和记录
+	for _, url := range result.Files {
+		if err := upload.Remove(ctx, s.config, url); err != nil {
+			return nil, fmt.Errorf("删除文件 %s 失败: %v", url, err)
+		}
+	}
+	if err := s.repo.DeleteByPaths(ctx, stale); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// referenceIndex 扫描内容，建立 上传文件路径 -> 引用列表 的索引
+// medias 非空时只扫描引用了这些文件（含衍生版本和下载地址）的内容，为空时扫描全部内容
+func (s *MediaService) referenceIndex(ctx context.Context, medias []*model.Media) (map[string][]model.MediaReference, error) {
+	var keywords []string
+	for _, media := range medias {
+		for _, url := range append([]string{media.Path}, media.Variants.URLs()...) {
+			keywords = append(keywords, url)
+			if key, ok := s.config.Storage.KeyFromURL(url); ok {
+				keywords = append(keywords, upload.DownloadURL(key))
+			}
+		}
+	}
+	if len(medias) > 0 && len(keywords) == 0 {
+		return map[string][]model.MediaReference{}, nil
+	}
+
+	sources, err := s.repo.ListContentSources(ctx, keywords)
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string][]model.MediaReference)
+	for _, source := range sources {
+		seen := make(map[string]bool)
+		for _, path := range s.refPattern.FindAllString(source.Text, -1) {
+			// 下载地址归到对应的文件地址
+			if key, ok := upload.KeyFromDownloadURL(path); ok {
+				path = s.config.Storage.URL(key)
+			}
+			if seen[path] {
+				continue
+			}
+			seen[path] = true
+			index[path] = append(index[path], model.MediaReference{
+				Type:  source.Type,
+				ID:    source.ID,
+				Title: source.Title,
+			})
+		}
+	}
+	return index, nil
+}
+
+// mediaReferences 汇总原图及其衍生版本被引用的情况（同一内容只计一次）
+func mediaReferences(refs map[string][]model.MediaReference, media *model.Media) []model.MediaReference {
+	var result []model.MediaReference
+	seen := make(map[model.MediaReference]bool)
+	for _, url := range append([]string{media.Path}, media.Variants.URLs()...) {
+		for _, ref := range refs[url] {
+			if !seen[ref] {
+				seen[ref] = true
+				result = append(result, ref)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/zyy125/my-blog/backend/internal/model"
+	"github.com/zyy125/my-blog/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// PageService 独立页面业务逻辑层
+type PageService struct {
+	repo        *repository.PageRepository
+	articleRepo *repository.ArticleRepository
+	tagCloud    *TagCloud // 标签云（转换后原文章被删除，需重新统计）
+}
+
+// NewPageService 创建页面服务实例
+func NewPageService(repo *repository.PageRepository, articleRepo *repository.ArticleRepository, tagCloud *TagCloud) *PageService {
+	return &PageService{
+		repo:        repo,
+		articleRepo: articleRepo,
+		tagCloud:    tagCloud,
+	}
+}
+
+// Create 创建页面
+func (s *PageService) Create(ctx context.Context, page *model.Page) error {
+	if err := s.validate(ctx, page); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, page)
+}
+
+// GetByID 获取页面详情（管理后台，包含草稿）
+func (s *PageService) GetByID(ctx context.Context, id uint) (*model.Page, error) {
+	page, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("页面不存在")
+		}
+		return nil, err
+	}
+	return page, nil
+}
+
+// GetBySlug 根据别名获取已发布的页面
+func (s *PageService) GetBySlug(ctx context.Context, slug string) (*model.Page, error) {
+	page, err := s.repo.GetBySlug(ctx, strings.ToLower(strings.TrimSpace(slug)))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("页面不存在")
+		}
+		return nil, err
+	}
+	if page.Status != 1 {
+		return nil, errors.New("页面不存在")
+	}
+	return page, nil
+}
+
+// List 获取页面列表（管理后台）
+func (s *PageService) List(ctx context.Context, status *int8) ([]*model.Page, error) {
+	return s.repo.List(ctx, status)
+}
+
+// ListMenu 获取菜单中的页面（已发布，不含正文）
+func (s *PageService) ListMenu(ctx context.Context) ([]*model.Page, error) {
+	return s.repo.ListMenu(ctx)
+}
+
+// Update 更新页面
+func (s *PageService) Update(ctx context.Context, page *model.Page) error {
+	// 1. 检查是否存在
+	existing, err := s.repo.GetByID(ctx, page.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("页面不存在")
+		}
+		return err
+	}
+
+	// 2. 验证
+	if err := s.validate(ctx, page); err != nil {
+		return err
+	}
+
+	// 3. 保留创建时间
+	page.CreatedAt = existing.CreatedAt
+
+	// 4. 更新
+	return s.repo.Update(ctx, page)
+}
+
+// Delete 删除页面
+func (s *PageService) Delete(ctx context.Context, id uint) error {
+	_, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("页面不存在")
+		}
+		return err
+	}
+
+	return s.repo.Delete(ctx, id)
+}
+
+// ConvertFromArticle 把误存为文章的页面转换为独立页面，原文章会被删除
+// 加密文章和有其他语言版本的文章不能转换
+func (s *PageService) ConvertFromArticle(ctx context.Context, articleID uint, slug string, menuOrder int) (*model.Page, error) {
+	article, err := s.articleRepo.GetByID(ctx, articleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("文章不存在")
+		}
+		return nil, err
+	}
+
+	// 页面没有访问密码，加密文章转换后正文会公开
+	if article.IsProtected {
+		return nil, errors.New("加密文章不能转换为页面，请先取消加密")
+	}
+	// 删除翻译组中的文章会使其他语言版本的关联失效
+	group, err := s.articleRepo.ListByTranslationGroup(ctx, article.TranslationGroup())
+	if err != nil {
+		return nil, err
+	}
+	if len(group) > 1 {
+		return nil, errors.New("该文章有其他语言版本，请先解除翻译关联")
+	}
+
+	if slug == "" {
+		slug = article.Slug
+	}
+	page := &model.Page{
+		Title:     article.Title,
+		Slug:      slug,
+		Content:   article.Content,
+		MenuOrder: menuOrder,
+		Status:    article.Status,
+		CreatedAt: article.CreatedAt,
+	}
+	if err := s.validate(ctx, page); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateFromArticle(ctx, page, articleID); err != nil {
+		return nil, err
+	}
+	s.tagCloud.Invalidate()
+	return page, nil
+}
+
+// validate 验证页面字段并检查别名是否重复
+func (s *PageService) validate(ctx context.Context, page *model.Page) error {
+	if page.Title == "" {
+		return errors.New("标题不能为空")
+	}
+	if page.Content == "" {
+		return errors.New("内容不能为空")
+	}
+
+	page.Slug = strings.ToLower(strings.TrimSpace(page.Slug))
+	if page.Slug == "" {
+		return errors.New("别名不能为空")
+	}
+	if !slugPattern.MatchString(page.Slug) {
+		return errors.New("别名只能包含小写字母、数字和连字符")
+	}
+
+	existing, err := s.repo.GetBySlug(ctx, page.Slug)
+	if err == nil && existing != nil && existing.ID != page.ID {
+		return errors.New("别名已存在")
+	}
+	return nil
+}
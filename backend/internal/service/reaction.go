@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/zyy125/my-blog/backend/internal/model"
+	"github.com/zyy125/my-blog/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// defaultReactionTypes 未配置时使用的表态列表
+var defaultReactionTypes = []string{"👍", "❤️", "🎉", "😄", "🤔"}
+
+// ReactionResult 表态操作结果
+type ReactionResult struct {
+	Reactions map[string]int64 `json:"reactions"` // 文章各类表态数量
+	Mine      []string         `json:"mine"`      // 当前访客已有的表态
+}
+
+// ReactionService 文章表态业务逻辑层
+type ReactionService struct {
+	repo        *repository.ReactionRepository
+	articleRepo *repository.ArticleRepository
+	types       []string // 可用的表态类型
+}
+
+// NewReactionService 创建表态服务实例（types 为空时使用默认表态）
+func NewReactionService(
+	repo *repository.ReactionRepository,
+	articleRepo *repository.ArticleRepository,
+	types []string,
+) *ReactionService {
+	if len(types) == 0 {
+		types = defaultReactionTypes
+	}
+	return &ReactionService{
+		repo:        repo,
+		articleRepo: articleRepo,
+		types:       types,
+	}
+}
+
+// Types 获取可用的表态类型
+func (s *ReactionService) Types() []string {
+	return s.types
+}
+
+// Add 访客对文章表态
+func (s *ReactionService) Add(ctx context.Context, articleID uint, reactionType, fingerprint string) (*ReactionResult, error) {
+	if err := s.validate(ctx, articleID, reactionType); err != nil {
+		return nil, err
+	}
+
+	reaction := &model.Reaction{
+		ArticleID:   articleID,
+		Type:        reactionType,
+		Fingerprint: fingerprint,
+	}
+	if err := s.repo.Create(ctx, reaction); err != nil {
+		return nil, err
+	}
+
+	return s.result(ctx, articleID, fingerprint)
+}
+
+// Remove 访客取消对文章的表态
+func (s *ReactionService) Remove(ctx context.Context, articleID uint, reactionType, fingerprint string) (*ReactionResult, error) {
+	if err := s.validate(ctx, articleID, reactionType); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Delete(ctx, articleID, reactionType, fingerprint); err != nil {
+		return nil, err
+	}
+
+	return s.result(ctx, articleID, fingerprint)
+}
+
+// Totals 获取全站各类表态数量
+func (s *ReactionService) Totals(ctx context.Context) ([]repository.ReactionCount, error) {
+	return s.repo.CountByType(ctx)
+}
+
+// validate 验证文章和表态类型
+func (s *ReactionService) validate(ctx context.Context, articleID uint, reactionType string) error {
+	// 1. 验证表态类型
+	allowed := false
+	for _, t := range s.types {
+		if t == reactionType {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return errors.New("不支持的表态类型")
+	}
+
+	// 2. 验证文章是否存在且已发布
+	article, err := s.articleRepo.GetByID(ctx, articleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("文章不存在")
+		}
+		return err
+	}
+	if article.Status != 1 {
+		return errors.New("文章不存在")
+	}
+	return nil
+}
+
+// result 查询文章最新的表态统计和当前访客的表态
+func (s *ReactionService) result(ctx context.Context, articleID uint, fingerprint string) (*ReactionResult, error) {
+	counts, err := countReactions(ctx, s.repo, []uint{articleID})
+	if err != nil {
+		return nil, err
+	}
+	mine, err := s.repo.ListTypesByFingerprint(ctx, articleID, fingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReactionResult{Reactions: counts[articleID], Mine: mine}, nil
+}
+
+// countReactions 批量统计文章表态数量（文章 ID -> 表态类型 -> 数量）
+func countReactions(ctx context.Context, repo *repository.ReactionRepository, articleIDs []uint) (map[uint]map[string]int64, error) {
+	rows, err := repo.CountByArticles(ctx, articleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uint]map[string]int64, len(articleIDs))
+	for _, id := range articleIDs {
+		counts[id] = make(map[string]int64)
+	}
+	for _, row := range rows {
+		counts[row.ArticleID][row.Type] = row.Count
+	}
+	return counts, nil
+}
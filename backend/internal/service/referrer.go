@@ -0,0 +1,59 @@
+package service
+
+import (
+	"net"
+	"net/url"
+	"strings"
+)
+
+const (
+	referrerDirect   = "direct"   // 无来源（直接访问、书签等）
+	referrerInternal = "internal" // 站内跳转
+)
+
+// normalizeReferrer 把来源 URL 归一化为域名（小写、去掉端口和 www. 前缀）
+func normalizeReferrer(raw, siteHost string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return referrerDirect
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		// 兼容不带协议的来源，如 "example.com/path"
+		u, err = url.Parse("http://" + raw)
+		if err != nil || u.Host == "" {
+			return referrerDirect
+		}
+	}
+
+	host := normalizeHost(u.Host)
+	if host == "" {
+		return referrerDirect
+	}
+	if siteHost != "" && host == normalizeHost(siteHost) {
+		return referrerInternal
+	}
+	if len(host) > 100 {
+		host = host[:100]
+	}
+	return host
+}
+
+// normalizeHost 去掉端口和 www. 前缀并转为小写
+func normalizeHost(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	return strings.TrimPrefix(host, "www.")
+}
+
+// normalizeUTM 归一化 utm 参数（去空白、小写、截断到 maxLen 字节）
+func normalizeUTM(value string, maxLen int) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if len(value) > maxLen {
+		value = strings.ToValidUTF8(value[:maxLen], "")
+	}
+	return value
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zyy125/my-blog/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+const maxTrendDays = 365 // 趋势查询最多天数
+
+// DailyViews 每日浏览量（用于趋势图）
+type DailyViews struct {
+	Date  string `json:"date"` // 日期（YYYY-MM-DD）
+	Views int64  `json:"views"`
+}
+
+// ReferrerReport 来源统计报表
+type ReferrerReport struct {
+	Referrers []repository.ReferrerCount `json:"referrers"` // 来源域名排行
+	Campaigns []repository.CampaignCount `json:"campaigns"` // 推广活动排行
+}
+
+// StatsService 统计业务逻辑层
+type StatsService struct {
+	viewRepo    *repository.ArticleViewRepository
+	articleRepo *repository.ArticleRepository
+}
+
+// NewStatsService 创建统计服务实例
+func NewStatsService(
+	viewRepo *repository.ArticleViewRepository,
+	articleRepo *repository.ArticleRepository,
+) *StatsService {
+	return &StatsService{
+		viewRepo:    viewRepo,
+		articleRepo: articleRepo,
+	}
+}
+
+// ArticleViewTrend 获取文章最近 days 天的每日浏览量
+func (s *StatsService) ArticleViewTrend(ctx context.Context, articleID uint, days int) ([]DailyViews, error) {
+	// 验证文章是否存在
+	_, err := s.articleRepo.GetByID(ctx, articleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("文章不存在")
+		}
+		return nil, err
+	}
+
+	start, end := recentDays(days)
+	rows, err := s.viewRepo.DailyByArticle(ctx, articleID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return fillDailySeries(rows, start, end), nil
+}
+
+// SiteViewTrend 获取全站最近 days 天的每日浏览量
+func (s *StatsService) SiteViewTrend(ctx context.Context, days int) ([]DailyViews, error) {
+	start, end := recentDays(days)
+	rows, err := s.viewRepo.DailyTotal(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return fillDailySeries(rows, start, end), nil
+}
+
+// TopArticles 获取指定日期所在日/周/月浏览量最高的文章
+// period 取值：day、week（周一至周日）、month
+func (s *StatsService) TopArticles(ctx context.Context, period string, date time.Time, limit int) ([]repository.ArticleViewCount, error) {
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	start := truncateToDay(date)
+	var end time.Time
+	switch period {
+	case "", "day":
+		end = start
+	case "week":
+		// 以周一为一周的开始
+		offset := (int(start.Weekday()) + 6) % 7
+		start = start.AddDate(0, 0, -offset)
+		end = start.AddDate(0, 0, 6)
+	case "month":
+		start = time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, start.Location())
+		end = start.AddDate(0, 1, -1)
+	default:
+		return nil, errors.New("统计周期只能是 day、week 或 month")
+	}
+
+	return s.viewRepo.TopArticles(ctx, start, end, limit)
+}
+
+// ReferrerReport 获取最近 days 天的来源和推广活动排行（articleID 为 nil 时统计全站）
+func (s *StatsService) ReferrerReport(ctx context.Context, articleID *uint, days, limit int) (*ReferrerReport, error) {
+	if articleID != nil {
+		_, err := s.articleRepo.GetByID(ctx, *articleID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("文章不存在")
+			}
+			return nil, err
+		}
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	start, end := recentDays(days)
+	referrers, err := s.viewRepo.TopReferrers(ctx, articleID, start, end, limit)
+	if err != nil {
+		return nil, err
+	}
+	campaigns, err := s.viewRepo.TopCampaigns(ctx, articleID, start, end, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReferrerReport{Referrers: referrers, Campaigns: campaigns}, nil
+}
+
+// recentDays 计算最近 days 天（含今天）的起止日期
+func recentDays(days int) (time.Time, time.Time) {
+	if days < 1 || days > maxTrendDays {
+		days = 30
+	}
+	end := truncateToDay(time.Now())
+	start := end.AddDate(0, 0, -(days - 1))
+	return start, end
+}
+
+// fillDailySeries 生成连续的每日序列，没有数据的日期补 0
+func fillDailySeries(rows []repository.DailyViewCount, start, end time.Time) []DailyViews {
+	viewsByDate := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		viewsByDate[row.Date.Format("2006-01-02")] += row.Views
+	}
+
+	var series []DailyViews
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		series = append(series, DailyViews{Date: date, Views: viewsByDate[date]})
+	}
+	return series
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/zyy125/my-blog/backend/internal/pkg/upload"
+	"github.com/zyy125/my-blog/backend/internal/repository"
+)
+
+// StorageUsageStore 基于数据库的存储用量记录（实现 upload.UsageStore，多实例共享配额）
+type StorageUsageStore struct {
+	repo *repository.StorageUsageRepository
+}
+
+// NewStorageUsageStore 创建存储用量记录
+func NewStorageUsageStore(repo *repository.StorageUsageRepository) *StorageUsageStore {
+	return &StorageUsageStore{repo: repo}
+}
+
+// Loaded 是否已登记过存储中的文件
+func (s *StorageUsageStore) Loaded(ctx context.Context) (bool, error) {
+	return s.repo.Loaded(ctx)
+}
+
+// Load 登记存储中已有的文件
+func (s *StorageUsageStore) Load(ctx context.Context, objects map[string]int64) error {
+	return s.repo.Load(ctx, objects)
+}
+
+// Reserve 登记对象键并占用空间，超过配额时返回 upload.ErrQuotaExceeded
+func (s *StorageUsageStore) Reserve(ctx context.Context, key string, size, quota int64) (bool, error) {
+	reserved, exceeded, err := s.repo.Reserve(ctx, key, size, quota)
+	if err != nil {
+		return false, err
+	}
+	if exceeded {
+		return false, upload.ErrQuotaExceeded
+	}
+	return reserved, nil
+}
+
+// Release 注销对象键并释放空间
+func (s *StorageUsageStore) Release(ctx context.Context, key string) error {
+	return s.repo.Release(ctx, key)
+}
+
+// ReleaseStale 注销登记时间早于 before 的对象键
+func (s *StorageUsageStore) ReleaseStale(ctx context.Context, key string, before time.Time) (bool, error) {
+	return s.repo.ReleaseStale(ctx, key, before)
+}
+
+// Total 返回已用空间和文件数
+func (s *StorageUsageStore) Total(ctx context.Context) (int64, int64, error) {
+	return s.repo.Total(ctx)
+}
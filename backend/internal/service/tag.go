@@ -3,6 +3,9 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
 	"github.com/zyy125/my-blog/backend/internal/model"
 	"github.com/zyy125/my-blog/backend/internal/repository"
 	"gorm.io/gorm"
@@ -11,15 +14,17 @@ import (
 // TagService 标签业务逻辑层
 type TagService struct {
 	repo *repository. TagRepository
+	cloud *TagCloud // 标签云（标签变更时失效）
 }
 
 // NewTagService 创建标签服务实例
-func NewTagService(repo *repository.TagRepository) *TagService {
-	return &TagService{repo: repo}
+func NewTagService(repo *repository.TagRepository, cloud *TagCloud) *TagService {
+	return &TagService{repo: repo, cloud: cloud}
 }
 
 // Create 创建标签
 func (s *TagService) Create(ctx context.Context, tag *model.Tag) error {
+	tag.Name = normalizeTagName(tag.Name)
 	if tag.Name == "" {
 		return errors.New("标签名称不能为空")
 	}
@@ -30,7 +35,22 @@ func (s *TagService) Create(ctx context.Context, tag *model.Tag) error {
 		return errors.New("标签名称已存在")
 	}
 	
-	return s.repo.Create(ctx, tag)
+	// 检查名称是否已是其他标签的别名
+	if err := s.checkAliasConflict(ctx, tag.Name); err != nil {
+		return err
+	}
+	if err := s.prepareSlug(ctx, tag); err != nil {
+		return err
+	}
+	
+	// 并发请求使用相同名称或别名时由唯一索引拒绝
+	if err := s.repo.Create(ctx, tag); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errors.New("标签名称或 URL 别名已存在")
+		}
+		return err
+	}
+	return nil
 }
 
 // GetByID 获取标签详情
@@ -45,13 +65,25 @@ func (s *TagService) GetByID(ctx context.Context, id uint) (*model.Tag, error) {
 	return tag, nil
 }
 
+// GetBySlug 根据 URL 别名获取标签详情
+func (s *TagService) GetBySlug(ctx context.Context, slug string) (*model.Tag, error) {
+	tag, err := s.repo.GetBySlug(ctx, strings.ToLower(strings.TrimSpace(slug)))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("标签不存在")
+		}
+		return nil, err
+	}
+	return tag, nil
+}
+
 // List 获取所有标签
 func (s *TagService) List(ctx context.Context) ([]*model.Tag, error) {
 	return s.repo.List(ctx)
 }
 
-// Update 更新标签
-func (s *TagService) Update(ctx context. Context, tag *model.Tag) error {
+// Update 更新标签，columns 为需要写入的列（tag 需包含其余字段的当前值，用于校验）
+func (s *TagService) Update(ctx context. Context, tag *model.Tag, columns []string) error {
 	_, err := s.repo.GetByID(ctx, tag.ID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -60,6 +92,7 @@ func (s *TagService) Update(ctx context. Context, tag *model.Tag) error {
 		return err
 	}
 	
+	tag.Name = normalizeTagName(tag.Name)
 	if tag.Name == "" {
 		return errors.New("标签名称不能为空")
 	}
@@ -70,7 +103,25 @@ func (s *TagService) Update(ctx context. Context, tag *model.Tag) error {
 		return errors.New("标签名称已存在")
 	}
 	
-	return s.repo.Update(ctx, tag)
+	// 检查名称是否已是别名
+	if err := s.checkAliasConflict(ctx, tag.Name); err != nil {
+		return err
+	}
+	if err := s.prepareSlug(ctx, tag); err != nil {
+		return err
+	}
+	
+	if len(columns) == 0 {
+		return nil
+	}
+	if err := s.repo.Update(ctx, tag, columns); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errors.New("标签名称或 URL 别名已存在")
+		}
+		return err
+	}
+	s.cloud.Invalidate()
+	return nil
 }
 
 // Delete 删除标签
@@ -85,10 +136,185 @@ func (s *TagService) Delete(ctx context.Context, id uint) error {
 	
 	// 注意：删除标签不检查文章关联
 	// 因为多对多关系，删除标签时会自动删除关联关系（CASCADE）
-	return s.repo.Delete(ctx, id)
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.cloud.Invalidate()
+	return nil
 }
 
 // ListWithCount 获取标签列表（带文章数量）
 func (s *TagService) ListWithCount(ctx context.Context) ([]map[string]interface{}, error) {
 	return s.repo. ListWithArticleCount(ctx)
-}
\ No newline at end of file
+}
+
+// GetByName 根据名称或别名查询标签（别名解析为对应的标准标签）
+func (s *TagService) GetByName(ctx context.Context, name string) (*model.Tag, error) {
+	tag, err := s.repo.GetByNameOrAlias(ctx, normalizeTagName(name))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("标签不存在")
+		}
+		return nil, err
+	}
+	return tag, nil
+}
+
+// Cloud 获取标签云（只统计已发布文章，结果缓存到文章或标签变更为止）
+func (s *TagService) Cloud(ctx context.Context, minCount int64, top int) ([]TagCloudItem, error) {
+	return s.cloud.Get(ctx, minCount, top)
+}
+
+// Suggest 按前缀匹配标签名称和别名（不区分大小写），用于输入时自动补全
+func (s *TagService) Suggest(ctx context.Context, prefix string, limit int) ([]*model.Tag, error) {
+	prefix = normalizeTagName(prefix)
+	if prefix == "" {
+		return []*model.Tag{}, nil
+	}
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+	return s.repo.Suggest(ctx, prefix, limit)
+}
+
+// Merge 将标签合并到目标标签（文章关联去重转移，源标签名称成为目标标签的别名）
+func (s *TagService) Merge(ctx context.Context, id, targetID uint) error {
+	if id == targetID {
+		return errors.New("不能合并到自身")
+	}
+	source, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("标签不存在")
+		}
+		return err
+	}
+	if _, err := s.repo.GetByID(ctx, targetID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("目标标签不存在")
+		}
+		return err
+	}
+	
+	if err := s.repo.Merge(ctx, source, targetID); err != nil {
+		return err
+	}
+	s.cloud.Invalidate()
+	return nil
+}
+
+// AddAlias 为标签添加别名
+func (s *TagService) AddAlias(ctx context.Context, tagID uint, alias string) (*model.TagAlias, error) {
+	// 1. 检查标签是否存在
+	if _, err := s.repo.GetByID(ctx, tagID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("标签不存在")
+		}
+		return nil, err
+	}
+	
+	// 2. 别名不能与已有标签名称或别名重复
+	alias = normalizeTagName(alias)
+	if alias == "" {
+		return nil, errors.New("别名不能为空")
+	}
+	if _, err := s.repo.GetByName(ctx, alias); err == nil {
+		return nil, errors.New("已存在同名标签，请使用合并")
+	}
+	if err := s.checkAliasConflict(ctx, alias); err != nil {
+		return nil, err
+	}
+	
+	// 3. 创建
+	record := &model.TagAlias{TagID: tagID, Alias: alias}
+	if err := s.repo.CreateAlias(ctx, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// DeleteAlias 删除标签别名
+func (s *TagService) DeleteAlias(ctx context.Context, tagID, aliasID uint) error {
+	deleted, err := s.repo.DeleteAlias(ctx, tagID, aliasID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return errors.New("别名不存在")
+	}
+	return nil
+}
+
+// prepareSlug 规范化并校验 URL 别名（可为空，空别名保存为 NULL；不能与其他标签重复）
+func (s *TagService) prepareSlug(ctx context.Context, tag *model.Tag) error {
+	tag.Slug = normalizeSlug(tag.Slug)
+	if tag.Slug == nil {
+		return nil
+	}
+	if !slugPattern.MatchString(*tag.Slug) {
+		return errors.New("URL 别名只能包含小写字母、数字和连字符")
+	}
+	existing, err := s.repo.GetBySlug(ctx, *tag.Slug)
+	if err == nil && existing.ID != tag.ID {
+		return errors.New("URL 别名已被其他标签使用")
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
+
+// checkAliasConflict 检查名称是否已被用作别名
+func (s *TagService) checkAliasConflict(ctx context.Context, name string) error {
+	alias, err := s.repo.GetAlias(ctx, name)
+	if err == nil {
+		tag, err := s.repo.GetByID(ctx, alias.TagID)
+		if err != nil {
+			return errors.New("该名称已被用作标签别名")
+		}
+		return errors.New("该名称已是标签「" + tag.Name + "」的别名")
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
+
+// maxTagNameLength 标签名称最大长度（与数据库字段长度一致）
+const maxTagNameLength = 50
+
+// normalizeTagName 规范化标签名称：全角字符转为半角，去除首尾空白并合并连续空白
+func normalizeTagName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case r == '\u3000': // 全角空格
+			r = ' '
+		case r >= '\uFF01' && r <= '\uFF5E': // 全角 ASCII 字符
+			r -= 0xFEE0
+		}
+		b.WriteRune(r)
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// normalizeTagNames 规范化标签名称列表，忽略空名称并按不区分大小写去重
+func normalizeTagNames(names []string) ([]string, error) {
+	var result []string
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = normalizeTagName(name)
+		if name == "" {
+			continue
+		}
+		if utf8.RuneCountInString(name) > maxTagNameLength {
+			return nil, fmt.Errorf("标签名称不能超过%d个字符", maxTagNameLength)
+		}
+		key := strings.ToLower(name)
+		if !seen[key] {
+			seen[key] = true
+			result = append(result, name)
+		}
+	}
+	return result, nil
+}
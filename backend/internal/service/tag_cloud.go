@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/zyy125/my-blog/backend/internal/repository"
+)
+
+// TagCloudLevels 标签云的权重档位数（权重取值 1~TagCloudLevels）
+const TagCloudLevels = 5
+
+// tagCloudTTL 标签云缓存的有效期
+const tagCloudTTL = time.Minute
+
+// TagCloudItem 标签云中的一个标签
+type TagCloudItem struct {
+	ID     uint    `json:"id"`
+	Name   string  `json:"name"`
+	Slug   *string `json:"slug"`
+	Count  int64   `json:"count"`  // 已发布文章数
+	Weight int     `json:"weight"` // 显示权重（按文章数的对数分档）
+}
+
+// TagCloud 标签云：缓存各标签的已发布文章数，文章或标签关联变化时需调用 Invalidate
+// 缓存只在本实例内有效，Invalidate 无法通知其他实例，因此缓存最多保留 tagCloudTTL，
+// 多实例部署时其他实例的变更最迟在有效期过后可见
+type TagCloud struct {
+	repo *repository.TagRepository
+
+	mu        sync.Mutex
+	counts    []repository.TagCount // 为 nil 表示缓存已失效
+	expiresAt time.Time
+}
+
+// NewTagCloud 创建标签云
+func NewTagCloud(repo *repository.TagRepository) *TagCloud {
+	return &TagCloud{repo: repo}
+}
+
+// Get 返回标签云：只包含已发布文章数不少于 minCount 的标签，top 大于 0 时只保留文章数最多的 top 个
+// 结果按文章数从多到少排列
+func (t *TagCloud) Get(ctx context.Context, minCount int64, top int) ([]TagCloudItem, error) {
+	// 1. 读取（或重新统计）文章数
+	counts, err := t.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 按文章数排序并筛选
+	items := make([]TagCloudItem, 0, len(counts))
+	for _, c := range counts {
+		if c.Count >= minCount && c.Count > 0 {
+			items = append(items, TagCloudItem{ID: c.ID, Name: c.Name, Slug: c.Slug, Count: c.Count})
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Count != items[j].Count {
+			return items[i].Count > items[j].Count
+		}
+		return items[i].Name < items[j].Name
+	})
+	if top > 0 && len(items) > top {
+		items = items[:top]
+	}
+
+	// 3. 计算权重
+	assignTagCloudWeights(items)
+	return items, nil
+}
+
+// Invalidate 使缓存失效，下次查询时重新统计
+func (t *TagCloud) Invalidate() {
+	t.mu.Lock()
+	t.counts = nil
+	t.mu.Unlock()
+}
+
+// load 返回缓存的文章数，缓存失效或过期时从数据库重新统计
+func (t *TagCloud) load(ctx context.Context) ([]repository.TagCount, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.counts == nil || time.Now().After(t.expiresAt) {
+		counts, err := t.repo.ListPublishedCounts(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if counts == nil {
+			counts = []repository.TagCount{}
+		}
+		t.counts = counts
+		t.expiresAt = time.Now().Add(tagCloudTTL)
+	}
+	return t.counts, nil
+}
+
+// assignTagCloudWeights 按文章数的对数在最小值和最大值之间均匀分档
+// 文章数都相同时全部使用中间档
+func assignTagCloudWeights(items []TagCloudItem) {
+	if len(items) == 0 {
+		return
+	}
+	minLog, maxLog := math.Inf(1), math.Inf(-1)
+	for _, item := range items {
+		v := math.Log(float64(item.Count))
+		minLog, maxLog = math.Min(minLog, v), math.Max(maxLog, v)
+	}
+	for i := range items {
+		if maxLog == minLog {
+			items[i].Weight = (TagCloudLevels + 1) / 2
+			continue
+		}
+		ratio := (math.Log(float64(items[i].Count)) - minLog) / (maxLog - minLog)
+		items[i].Weight = 1 + int(math.Round(ratio*(TagCloudLevels-1)))
+	}
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zyy125/my-blog/backend/internal/model"
+	"github.com/zyy125/my-blog/backend/internal/repository"
+)
+
+const (
+	defaultViewDedupWindow   = 30 * time.Minute
+	defaultViewFlushInterval = time.Minute
+)
+
+// crawlerKeywords 常见爬虫 User-Agent 关键字（小写）
+var crawlerKeywords = []string{
+	"bot", "spider", "crawl", "slurp", "archiver", "facebookexternalhit",
+	"preview", "headless", "curl", "wget", "python-requests", "go-http-client",
+	"java/", "okhttp", "httpclient", "scrapy", "lighthouse", "pingdom",
+}
+
+// ViewInfo 一次文章浏览的访客信息
+type ViewInfo struct {
+	IP          string
+	UserAgent   string
+	Referrer    string // 来源页 URL
+	SiteHost    string // 本站域名（来自本站的来源视为站内跳转）
+	UTMSource   string
+	UTMMedium   string
+	UTMCampaign string
+}
+
+// viewDay 按文章和日期聚合浏览量的键
+type viewDay struct {
+	articleID uint
+	date      time.Time // 当天零点（本地时区）
+}
+
+// referrerDay 按文章、日期和来源聚合浏览量的键
+type referrerDay struct {
+	viewDay
+	referrer    string
+	utmSource   string
+	utmMedium   string
+	utmCampaign string
+}
+
+// ViewCounter 浏览量聚合器
+// 在内存中按文章累计浏览量，同一访客（IP + User-Agent 哈希）在去重窗口内
+// 重复浏览只计一次，忽略爬虫，并定时批量写入数据库
+type ViewCounter struct {
+	repo          *repository.ArticleRepository
+	window        time.Duration // 去重窗口
+	flushInterval time.Duration // 批量写入间隔
+
+	mu      sync.Mutex
+	seen    map[[sha256.Size]byte]time.Time // 访客+文章哈希 -> 最近一次计数时间
+	pending map[viewDay]int                 // 文章+日期 -> 待写入的浏览量增量
+	sources map[referrerDay]int             // 文章+日期+来源 -> 待写入的浏览量增量
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewViewCounter 创建浏览量聚合器并启动定时写入协程（window、flushInterval 为 0 时使用默认值）
+func NewViewCounter(repo *repository.ArticleRepository, window, flushInterval time.Duration) *ViewCounter {
+	if window <= 0 {
+		window = defaultViewDedupWindow
+	}
+	if flushInterval <= 0 {
+		flushInterval = defaultViewFlushInterval
+	}
+	vc := &ViewCounter{
+		repo:          repo,
+		window:        window,
+		flushInterval: flushInterval,
+		seen:          make(map[[sha256.Size]byte]time.Time),
+		pending:       make(map[viewDay]int),
+		sources:       make(map[referrerDay]int),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	go vc.run()
+	return vc
+}
+
+// Stop 停止定时写入并把剩余的浏览量写入数据库（优雅退出时调用）
+func (vc *ViewCounter) Stop() {
+	vc.stopOnce.Do(func() {
+		close(vc.stop)
+		<-vc.done
+	})
+}
+
+// Record 记录一次浏览，返回是否被计数
+func (vc *ViewCounter) Record(articleID uint, viewer ViewInfo) bool {
+	if isCrawler(viewer.UserAgent) {
+		return false
+	}
+
+	key := sha256.Sum256([]byte(viewer.IP + "|" + viewer.UserAgent + "|" + strconv.FormatUint(uint64(articleID), 10)))
+	now := time.Now()
+
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+
+	if last, exists := vc.seen[key]; exists && now.Sub(last) < vc.window {
+		return false
+	}
+	vc.seen[key] = now
+	day := viewDay{articleID: articleID, date: truncateToDay(now)}
+	vc.pending[day]++
+	vc.sources[referrerDay{
+		viewDay:     day,
+		referrer:    normalizeReferrer(viewer.Referrer, viewer.SiteHost),
+		utmSource:   normalizeUTM(viewer.UTMSource, 50),
+		utmMedium:   normalizeUTM(viewer.UTMMedium, 50),
+		utmCampaign: normalizeUTM(viewer.UTMCampaign, 100),
+	}]++
+	return true
+}
+
+// Flush 把累计的浏览量写入数据库
+func (vc *ViewCounter) Flush(ctx context.Context) error {
+	// 1. 取出待写入数据，并清理过期的去重记录
+	vc.mu.Lock()
+	pending, sources := vc.pending, vc.sources
+	vc.pending = make(map[viewDay]int)
+	vc.sources = make(map[referrerDay]int)
+	now := time.Now()
+	for key, last := range vc.seen {
+		if now.Sub(last) >= vc.window {
+			delete(vc.seen, key)
+		}
+	}
+	vc.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	// 2. 批量写入，失败时放回队列等待下次重试
+	daily := make([]model.ArticleViewDaily, 0, len(pending))
+	for key, count := range pending {
+		daily = append(daily, model.ArticleViewDaily{
+			ArticleID: key.articleID,
+			Date:      key.date,
+			Views:     count,
+		})
+	}
+	referrers := make([]model.ArticleReferrerDaily, 0, len(sources))
+	for key, count := range sources {
+		referrers = append(referrers, model.ArticleReferrerDaily{
+			ArticleID:   key.articleID,
+			Date:        key.date,
+			Referrer:    key.referrer,
+			UTMSource:   key.utmSource,
+			UTMMedium:   key.utmMedium,
+			UTMCampaign: key.utmCampaign,
+			Views:       count,
+		})
+	}
+	if err := vc.repo.IncrementViewsBatch(ctx, daily, referrers); err != nil {
+		vc.mu.Lock()
+		for key, count := range pending {
+			vc.pending[key] += count
+		}
+		for key, count := range sources {
+			vc.sources[key] += count
+		}
+		vc.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// run 定时写入循环
+func (vc *ViewCounter) run() {
+	defer close(vc.done)
+
+	ticker := time.NewTicker(vc.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := vc.Flush(context.Background()); err != nil {
+				log.Printf("浏览量写入失败: %v", err)
+			}
+		case <-vc.stop:
+			if err := vc.Flush(context.Background()); err != nil {
+				log.Printf("浏览量写入失败: %v", err)
+			}
+			return
+		}
+	}
+}
+
+// isCrawler 判断 User-Agent 是否为爬虫（空 User-Agent 也视为爬虫）
+func isCrawler(userAgent string) bool {
+	ua := strings.ToLower(strings.TrimSpace(userAgent))
+	if ua == "" {
+		return true
+	}
+	for _, keyword := range crawlerKeywords {
+		if strings.Contains(ua, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// truncateToDay 截断到当天零点（本地时区）
+func truncateToDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
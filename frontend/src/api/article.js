@@ -6,8 +6,8 @@ export function getArticles(params) {
   return request.get('/api/articles', { params });
 }
 
-export function getArticleDetail(id) {
-  return request.get(`/api/articles/${id}`);
+export function getArticleDetail(id, params) {
+  return request.get(`/api/articles/${id}`, { params });
 }
 
 // 管理接口